@@ -0,0 +1,7 @@
+data "bunny_api_request" "regions" {
+  path = "region"
+}
+
+output "region_names" {
+  value = [for r in jsondecode(data.bunny_api_request.regions.response) : r.Name]
+}
@@ -0,0 +1,11 @@
+resource "bunny_api_request" "dnszone" {
+  path = "dnszone"
+  body = jsonencode({
+    Domain = "example.com"
+  })
+  id_path = "Id"
+
+  read_path   = "dnszone/{id}"
+  update_path = "dnszone/{id}"
+  delete_path = "dnszone/{id}"
+}
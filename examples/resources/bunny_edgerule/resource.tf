@@ -13,3 +13,44 @@ resource "bunny_edgerule" "myer" {
     pattern_matches       = ["50"]
   }
 }
+
+resource "bunny_edgerule" "redirect_old_blog" {
+  pull_zone_id          = bunny_pullzone.mypz.id
+  trigger_matching_type = "all"
+  trigger {
+    pattern_matching_type = "any"
+    type                  = "url"
+    pattern_matches       = ["*/blog/*"]
+  }
+
+  redirect {
+    url         = "https://blog.example.com"
+    status_code = 301
+  }
+}
+
+resource "bunny_edgerule" "assets" {
+  pull_zone_id          = bunny_pullzone.mypz.id
+  trigger_matching_type = "all"
+  trigger {
+    pattern_matching_type = "any"
+    type                  = "url"
+    pattern_matches       = ["*/assets/*"]
+  }
+
+  set_response_header {
+    name  = "X-Content-Type-Options"
+    value = "nosniff"
+  }
+
+  action {
+    type        = "set_response_header"
+    parameter_1 = "X-Frame-Options"
+    parameter_2 = "DENY"
+  }
+
+  action {
+    type        = "override_cache_time"
+    parameter_1 = "86400"
+  }
+}
@@ -0,0 +1 @@
+terraform import bunny_edgerule_preset.example "<PULLZONE-ID>|<PRESET>"
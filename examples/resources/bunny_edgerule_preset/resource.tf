@@ -0,0 +1,22 @@
+resource "bunny_pullzone" "mypz" {
+  name       = "testpz123aye"
+  origin_url = "https://bunny.net"
+}
+
+resource "bunny_edgerule_preset" "security_headers" {
+  pull_zone_id            = bunny_pullzone.mypz.id
+  preset                  = "security_headers"
+  content_security_policy = "default-src 'self'"
+}
+
+resource "bunny_edgerule_preset" "www_redirect" {
+  pull_zone_id = bunny_pullzone.mypz.id
+  preset       = "www_redirect"
+  domain       = "example.com"
+}
+
+resource "bunny_edgerule_preset" "cache_hashed_assets" {
+  pull_zone_id = bunny_pullzone.mypz.id
+  preset       = "cache_hashed_assets"
+  url_patterns = ["*/assets/*", "*/_next/static/*"]
+}
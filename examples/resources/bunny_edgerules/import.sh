@@ -0,0 +1 @@
+terraform import bunny_edgerules.example <PULLZONE-ID>
@@ -0,0 +1,37 @@
+resource "bunny_pullzone" "mypz" {
+  name       = "testpz123aye"
+  origin_url = "https://bunny.net"
+}
+
+resource "bunny_edgerules" "mypz" {
+  pull_zone_id = bunny_pullzone.mypz.id
+
+  edge_rule {
+    description           = "block admin"
+    action_type           = "block_request"
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "url"
+      pattern_matches       = ["*/admin/*"]
+    }
+  }
+
+  edge_rule {
+    description           = "cache assets"
+    action_type           = "override_cache_time"
+    action_parameter_1    = "86400"
+    trigger_matching_type = "all"
+    trigger {
+      pattern_matching_type = "any"
+      type                  = "url"
+      pattern_matches       = ["*/assets/*"]
+    }
+
+    action {
+      type        = "set_response_header"
+      parameter_1 = "Cache-Control"
+      parameter_2 = "public, max-age=86400"
+    }
+  }
+}
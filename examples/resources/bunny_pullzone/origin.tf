@@ -0,0 +1,14 @@
+resource "bunny_pullzone" "s3" {
+  name = "pz-s3"
+
+  origin {
+    type        = "url"
+    url         = "https://s3.eu-central-1.amazonaws.com"
+    host_header = "mybucket.s3.eu-central-1.amazonaws.com"
+
+    aws_signing_enabled     = true
+    aws_signing_key         = var.aws_access_key_id
+    aws_signing_secret      = var.aws_secret_access_key
+    aws_signing_region_name = "eu-central-1"
+  }
+}
@@ -0,0 +1,23 @@
+resource "bunny_pullzone" "mypz" {
+  name       = "testpz123aye"
+  origin_url = "https://bunny.net"
+}
+
+resource "bunny_redirect_map" "blog" {
+  pull_zone_id = bunny_pullzone.mypz.id
+
+  redirects = {
+    "/old-blog"  = "/blog"
+    "/news"      = "/blog"
+    "/old-about" = "https://example.com/about"
+  }
+}
+
+# redirects.csv:
+#   source,destination,status_code
+#   /products/old,/products/new,301
+#   /sale,/offers,302
+resource "bunny_redirect_map" "shop" {
+  pull_zone_id = bunny_pullzone.mypz.id
+  csv_file     = "${path.module}/redirects.csv"
+}
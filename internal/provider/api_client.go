@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/google/uuid"
+)
+
+// apiClient sends requests to the bunny.net HTTP API.
+// It is used for API endpoints and message fields that are not supported by
+// the bunny client library. Requests are authenticated with the same API key
+// and logged in the same way as requests sent via the bunny.Client.
+type apiClient struct {
+	baseURL    *url.URL
+	apiKey     string
+	userAgent  string
+	httpClient *http.Client
+}
+
+func newBunnyAPIClient(apiKey, userAgent string) *apiClient {
+	baseURL, err := url.Parse(bunny.BaseURL)
+	if err != nil {
+		panic(fmt.Sprintf("parsing bunny base url %q failed: %s", bunny.BaseURL, err))
+	}
+
+	return &apiClient{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		userAgent:  userAgent,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// do sends a request with the given method to the path.
+// If body is not nil, it is sent JSON encoded as HTTP body.
+// If result is not nil and the response has a body, it is unmarshaled into
+// result.
+// Unsuccessful responses are returned as *bunny.HTTPError or, if the
+// response contains an error message, as *bunny.APIError.
+func (c *apiClient) do(ctx context.Context, method, path string, body, result interface{}) error {
+	respBody, err := c.doRaw(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("could not parse response body as %T: %w", result, err)
+	}
+
+	return nil
+}
+
+// doRaw sends a request with the given method to the path and returns the
+// received response body.
+func (c *apiClient) doRaw(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	reqURL, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request body failed: %w", err)
+		}
+
+		reqBody = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set(bunny.AccessKeyHeaderKey, c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logReqID := c.logRequest(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	c.logResponse(resp, logReqID)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &bunny.HTTPError{
+			RequestURL: req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Errors:     []error{fmt.Errorf("reading response body failed: %w", err)},
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, respError(req, resp, respBody)
+	}
+
+	return respBody, nil
+}
+
+func respError(req *http.Request, resp *http.Response, respBody []byte) error {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &bunny.AuthenticationError{Message: string(respBody)}
+	}
+
+	httpErr := bunny.HTTPError{
+		RequestURL: req.URL.String(),
+		StatusCode: resp.StatusCode,
+		RespBody:   respBody,
+	}
+
+	if len(respBody) == 0 {
+		return &httpErr
+	}
+
+	var apiErr bunny.APIError
+	if err := json.Unmarshal(respBody, &apiErr); err != nil || apiErr.Message == "" {
+		return &httpErr
+	}
+
+	apiErr.HTTPError = httpErr
+	return &apiErr
+}
+
+func (c *apiClient) get(ctx context.Context, path string, result interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, result)
+}
+
+func (c *apiClient) post(ctx context.Context, path string, body, result interface{}) error {
+	return c.do(ctx, http.MethodPost, path, body, result)
+}
+
+// logRequest dumps the request with a hidden access key to the debug log and
+// returns an identifier for associating the response log message with it.
+func (c *apiClient) logRequest(req *http.Request) string {
+	logReqID := uuid.New().String()
+
+	req.Header.Set(bunny.AccessKeyHeaderKey, "***hidden***")
+	defer req.Header.Set(bunny.AccessKeyHeaderKey, c.apiKey)
+
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		logger.Debugf("dumping http request (reqID: %s) failed: %s", logReqID, err)
+		return logReqID
+	}
+
+	logger.Debugf("sending http-request (reqID: %s): %s", logReqID, string(dump))
+
+	return logReqID
+}
+
+func (c *apiClient) logResponse(resp *http.Response, logReqID string) {
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		logger.Debugf("dumping http response (reqID: %s) failed: %s", logReqID, err)
+		return
+	}
+
+	logger.Debugf("received http-response (reqID: %s): %s", logReqID, string(dump))
+}
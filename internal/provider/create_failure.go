@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const keyOnCreateFailure = "on_create_failure"
+
+// Values of the on_create_failure provider setting.
+const (
+	onCreateFailureDelete = "delete"
+	onCreateFailureKeep   = "keep"
+)
+
+var onCreateFailureValues = []string{onCreateFailureDelete, onCreateFailureKeep}
+
+// createFailed handles errors that happened after a resource was created via
+// the API but before it was configured completely. diags are the errors that
+// happened, del deletes the resource.
+//
+// If the on_create_failure provider setting is "delete", the resource is
+// deleted and removed from the state. Otherwise it is kept in the state,
+// Terraform marks it as tainted because an error is returned and replaces it
+// on the next apply.
+func createFailed(
+	ctx context.Context,
+	d *schema.ResourceData,
+	meta interface{},
+	resourceName string,
+	diags diag.Diagnostics,
+	del func(context.Context) error,
+) diag.Diagnostics {
+	if meta.(*providerMeta).onCreateFailure != onCreateFailureDelete {
+		if d.Id() == "" {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s was created but could not be stored in the state", resourceName),
+				Detail: fmt.Sprintf("The %s exists but is not managed by Terraform, import or delete it manually.",
+					resourceName,
+				),
+			})
+		}
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s was created but could not be configured completely", resourceName),
+			Detail: fmt.Sprintf("The %s is kept and marked as tainted, it will be replaced on the next apply. "+
+				"Set the provider setting %s to %q to delete it instead.",
+				resourceName, keyOnCreateFailure, onCreateFailureDelete,
+			),
+		})
+	}
+
+	if err := del(ctx); err != nil {
+		if d.Id() == "" {
+			return append(diags, diagsErrFromErr(
+				fmt.Sprintf("deleting the incompletely configured %s failed, delete it manually", resourceName), err,
+			)...)
+		}
+
+		return append(diags, diagsErrFromErr(
+			fmt.Sprintf("deleting the incompletely configured %s failed, it is kept and marked as tainted", resourceName), err,
+		)...)
+	}
+
+	d.SetId("")
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s was deleted because it could not be configured completely", resourceName),
+		Detail:   fmt.Sprintf("The provider setting %s is %q.", keyOnCreateFailure, onCreateFailureDelete),
+	})
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCreateFailed(t *testing.T) {
+	updateErr := diag.Errorf("update failed")
+
+	tests := []struct {
+		name            string
+		onCreateFailure string
+		deleteErr       error
+		wantDeleted     bool
+		wantID          string
+	}{
+		{
+			name:            "keep",
+			onCreateFailure: onCreateFailureKeep,
+			wantID:          "1",
+		},
+		{
+			name:            "delete",
+			onCreateFailure: onCreateFailureDelete,
+			wantDeleted:     true,
+			wantID:          "",
+		},
+		{
+			name:            "deleteFails",
+			onCreateFailure: onCreateFailureDelete,
+			deleteErr:       errors.New("delete failed"),
+			wantDeleted:     true,
+			wantID:          "1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceStorageZone().Schema, map[string]interface{}{})
+			d.SetId("1")
+
+			var deleted bool
+			diags := createFailed(context.Background(), d, &providerMeta{onCreateFailure: tc.onCreateFailure},
+				"storage zone", updateErr, func(context.Context) error {
+					deleted = true
+					return tc.deleteErr
+				},
+			)
+
+			if !diags.HasError() {
+				t.Error("expected diagnostics to contain an error")
+			}
+
+			if len(diags) <= len(updateErr) {
+				t.Errorf("expected diagnostics to contain an additional entry, got: %+v", diags)
+			}
+
+			if deleted != tc.wantDeleted {
+				t.Errorf("expected delete to be called: %t, was called: %t", tc.wantDeleted, deleted)
+			}
+
+			if d.Id() != tc.wantID {
+				t.Errorf("expected id to be %q, got %q", tc.wantID, d.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAPIRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAPIRequestRead,
+
+		Description: "Sends a request to an arbitrary endpoint of the bunny.net API and exposes the response. " +
+			"It is intended for features that are not supported by a dedicated data source. " +
+			"The request is authenticated with the API key of the provider.",
+
+		Schema: map[string]*schema.Schema{
+			keyAPIRequestPath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the API endpoint, relative to the bunny.net API base URL.",
+				Required:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestMethod: {
+				Type:             schema.TypeString,
+				Description:      "The HTTP method of the request.\nValid values: " + strings.Join(apiRequestDataSourceMethods, ", "),
+				Optional:         true,
+				Default:          http.MethodGet,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(apiRequestDataSourceMethods, false)),
+			},
+			keyAPIRequestBody: {
+				Type:             schema.TypeString,
+				Description:      "The JSON encoded body of the request.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			keyAPIRequestIDPath: {
+				Type:        schema.TypeString,
+				Description: "The dot-separated path of the field in the response that is used as ID of the data source. If not set, the path is used as ID.",
+				Optional:    true,
+			},
+			keyAPIRequestResponse: {
+				Type:        schema.TypeString,
+				Description: "The JSON encoded body of the response.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceAPIRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+	path := d.Get(keyAPIRequestPath).(string)
+
+	resp, err := apiRequestSend(ctx, clt, d.Get(keyAPIRequestMethod).(string), path, d.Get(keyAPIRequestBody).(string))
+	if err != nil {
+		return diagsErrFromErr("request failed", err)
+	}
+
+	id := path
+	if idPath := d.Get(keyAPIRequestIDPath).(string); idPath != "" {
+		id, err = jsonValueAtPath(resp, idPath)
+		if err != nil {
+			return diagsErrFromErr("could not retrieve id from response via "+keyAPIRequestIDPath, err)
+		}
+	}
+
+	d.SetId(id)
+
+	if err := d.Set(keyAPIRequestResponse, string(resp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
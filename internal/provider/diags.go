@@ -1,6 +1,11 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
 
 func diagsErrFromErr(summary string, err error) diag.Diagnostics {
 	return diagsFromErr(summary, err, diag.Error)
@@ -17,3 +22,28 @@ func diagsFromErr(summary string, err error, severity diag.Severity) diag.Diagno
 		Detail:   err.Error(),
 	}}
 }
+
+// errFromDiags returns the error diagnostics as error, warnings are ignored.
+// If diags contains no errors, nil is returned.
+func errFromDiags(diags diag.Diagnostics) error {
+	var msgs []string
+
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		if d.Detail == "" {
+			msgs = append(msgs, d.Summary)
+			continue
+		}
+
+		msgs = append(msgs, d.Summary+": "+d.Detail)
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(msgs, "; "))
+}
@@ -1,28 +1,98 @@
 package provider
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
 )
 
+// Action types that are supported by the bunny.net API but are not defined in
+// the client module.
+const (
+	edgeRuleActionTypeOverrideBrowserCacheTime  = 16
+	edgeRuleActionTypeOriginStorage             = 17
+	edgeRuleActionTypeSetNetworkRateLimit       = 18
+	edgeRuleActionTypeSetConnectionLimit        = 19
+	edgeRuleActionTypeSetRequestsPerSecondLimit = 20
+	edgeRuleActionTypeRunEdgeScript             = 21
+	edgeRuleActionTypeDisableShield             = 28
+)
+
 var edgeRuleActionTypesStr = map[string]int{
-	"force_ssl":                  bunny.EdgeRuleActionTypeForceSSL,
-	"redirect":                   bunny.EdgeRuleActionTypeRedirect,
-	"origin_url":                 bunny.EdgeRuleActionTypeOriginURL,
-	"override_cache_time":        bunny.EdgeRuleActionTypeOverrideCacheTime,
-	"block_request":              bunny.EdgeRuleActionTypeBlockRequest,
-	"set_response_header":        bunny.EdgeRuleActionTypeSetResponseHeader,
-	"set_request_header":         bunny.EdgeRuleActionTypeSetRequestHeader,
-	"force_download":             bunny.EdgeRuleActionTypeForceDownload,
-	"disable_token_auth":         bunny.EdgeRuleActionTypeDisableTokenAuthentication,
-	"enable_token_auth":          bunny.EdgeRuleActionTypeEnableTokenAuthentication,
-	"override_cache_time_public": bunny.EdgeRuleActionTypeOverrideCacheTimePublic,
-	"ignore_query_string":        bunny.EdgeRuleActionTypeIgnoreQueryString,
-	"disable_optimizer":          bunny.EdgeRuleActionTypeDisableOptimizer,
-	"force_compression":          bunny.EdgeRuleActionTypeForceCompression,
-	"set_status_code":            bunny.EdgeRuleActionTypeSetStatusCode,
-	"bypass_perma_cache":         bunny.EdgeRuleActionTypeBypassPermaCache,
+	"force_ssl":                     bunny.EdgeRuleActionTypeForceSSL,
+	"redirect":                      bunny.EdgeRuleActionTypeRedirect,
+	"origin_url":                    bunny.EdgeRuleActionTypeOriginURL,
+	"override_cache_time":           bunny.EdgeRuleActionTypeOverrideCacheTime,
+	"block_request":                 bunny.EdgeRuleActionTypeBlockRequest,
+	"set_response_header":           bunny.EdgeRuleActionTypeSetResponseHeader,
+	"set_request_header":            bunny.EdgeRuleActionTypeSetRequestHeader,
+	"force_download":                bunny.EdgeRuleActionTypeForceDownload,
+	"disable_token_auth":            bunny.EdgeRuleActionTypeDisableTokenAuthentication,
+	"enable_token_auth":             bunny.EdgeRuleActionTypeEnableTokenAuthentication,
+	"override_cache_time_public":    bunny.EdgeRuleActionTypeOverrideCacheTimePublic,
+	"ignore_query_string":           bunny.EdgeRuleActionTypeIgnoreQueryString,
+	"disable_optimizer":             bunny.EdgeRuleActionTypeDisableOptimizer,
+	"force_compression":             bunny.EdgeRuleActionTypeForceCompression,
+	"set_status_code":               bunny.EdgeRuleActionTypeSetStatusCode,
+	"bypass_perma_cache":            bunny.EdgeRuleActionTypeBypassPermaCache,
+	"override_browser_cache_time":   edgeRuleActionTypeOverrideBrowserCacheTime,
+	"origin_storage":                edgeRuleActionTypeOriginStorage,
+	"set_network_rate_limit":        edgeRuleActionTypeSetNetworkRateLimit,
+	"set_connection_limit":          edgeRuleActionTypeSetConnectionLimit,
+	"set_requests_per_second_limit": edgeRuleActionTypeSetRequestsPerSecondLimit,
+	"run_edge_script":               edgeRuleActionTypeRunEdgeScript,
+	"disable_shield":                edgeRuleActionTypeDisableShield,
 }
 
 var edgeRuleActionTypesInt = reverseStrIntMap(edgeRuleActionTypesStr)
 
 var edgeRuleActionTypeKeys = strIntMapKeysSorted(edgeRuleActionTypesStr)
+
+// edgeRuleActionParameter1Docs describes the value of action_parameter_1 per
+// action type, action types that are missing have no parameter.
+var edgeRuleActionParameter1Docs = map[string]string{
+	"origin_storage":                "ID of the Storage Zone",
+	"origin_url":                    "origin URL",
+	"override_browser_cache_time":   "browser cache time in seconds",
+	"override_cache_time":           "cache time in seconds",
+	"override_cache_time_public":    "cache time in seconds",
+	"redirect":                      "redirect URL",
+	"run_edge_script":               "ID of the Edge Script",
+	"set_connection_limit":          "maximum number of concurrent connections per client",
+	"set_network_rate_limit":        "bandwidth limit per connection in kB/s",
+	"set_request_header":            "header name",
+	"set_requests_per_second_limit": "maximum number of requests per second per client",
+	"set_response_header":           "header name",
+	"set_status_code":               "HTTP status code",
+}
+
+// edgeRuleActionParameter2Docs describes the value of action_parameter_2 per
+// action type, action types that are missing have no parameter.
+var edgeRuleActionParameter2Docs = map[string]string{
+	"redirect":            "HTTP status code of the redirect",
+	"set_request_header":  "header value",
+	"set_response_header": "header value",
+}
+
+// typeParameterDocs returns a description listing the values of docs in the
+// format "type: description", sorted by type.
+func typeParameterDocs(docs map[string]string) string {
+	types := make([]string, 0, len(docs))
+	for typ := range docs {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	var sb strings.Builder
+
+	for i, typ := range types {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s: %s", typ, docs[typ])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyEdgeRuleActionURL        = "url"
+	keyEdgeRuleActionStatusCode = "status_code"
+	keyEdgeRuleActionSeconds    = "seconds"
+	keyEdgeRuleActionName       = "name"
+	keyEdgeRuleActionValue      = "value"
+)
+
+// edgeRuleActionBlock is a typed block for an action type of an Edge Rule.
+// The block has the same name as the action type.
+type edgeRuleActionBlock struct {
+	resource *schema.Resource
+	// params returns the action parameters for the attributes of the
+	// block.
+	params func(m structure) (param1, param2 string)
+	// attrs returns the attributes of the block for the action
+	// parameters.
+	attrs func(param1, param2 string) map[string]interface{}
+}
+
+var validateEdgeRuleActionURL = validation.ToDiagFunc(validation.StringMatch(
+	regexp.MustCompile(`^https?://.+`), "must be an http:// or https:// URL",
+))
+
+var validateHTTPHeaderName = validation.ToDiagFunc(validation.StringMatch(
+	regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$"), "must be a valid HTTP header name",
+))
+
+var edgeRuleActionURLBlock = &edgeRuleActionBlock{
+	resource: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			keyEdgeRuleActionURL: {
+				Type:             schema.TypeString,
+				Description:      "The URL.",
+				Required:         true,
+				ValidateDiagFunc: validateEdgeRuleActionURL,
+			},
+		},
+	},
+	params: func(m structure) (string, string) {
+		return m.getStr(keyEdgeRuleActionURL), ""
+	},
+	attrs: func(param1, _ string) map[string]interface{} {
+		return map[string]interface{}{keyEdgeRuleActionURL: param1}
+	},
+}
+
+var edgeRuleActionCacheTimeBlock = &edgeRuleActionBlock{
+	resource: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			keyEdgeRuleActionSeconds: {
+				Type:             schema.TypeInt,
+				Description:      "The cache time in seconds.",
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+		},
+	},
+	params: func(m structure) (string, string) {
+		return strconv.Itoa(m.getInt(keyEdgeRuleActionSeconds)), ""
+	},
+	attrs: func(param1, _ string) map[string]interface{} {
+		return map[string]interface{}{keyEdgeRuleActionSeconds: atoiOrZero(param1)}
+	},
+}
+
+var edgeRuleActionHeaderBlock = &edgeRuleActionBlock{
+	resource: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			keyEdgeRuleActionName: {
+				Type:             schema.TypeString,
+				Description:      "The name of the header.",
+				Required:         true,
+				ValidateDiagFunc: validateHTTPHeaderName,
+			},
+			keyEdgeRuleActionValue: {
+				Type:        schema.TypeString,
+				Description: "The value of the header.",
+				Optional:    true,
+			},
+		},
+	},
+	params: func(m structure) (string, string) {
+		return m.getStr(keyEdgeRuleActionName), m.getStr(keyEdgeRuleActionValue)
+	},
+	attrs: func(param1, param2 string) map[string]interface{} {
+		return map[string]interface{}{
+			keyEdgeRuleActionName:  param1,
+			keyEdgeRuleActionValue: param2,
+		}
+	},
+}
+
+// edgeRuleActionBlocks contains the typed blocks, the keys are the action
+// types.
+var edgeRuleActionBlocks = map[string]*edgeRuleActionBlock{
+	"redirect": {
+		resource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyEdgeRuleActionURL: {
+					Type:             schema.TypeString,
+					Description:      "The URL to that requests are redirected.",
+					Required:         true,
+					ValidateDiagFunc: validateEdgeRuleActionURL,
+				},
+				keyEdgeRuleActionStatusCode: {
+					Type:        schema.TypeInt,
+					Description: "The HTTP status code of the redirect response.\nValid values: 301, 302, 307, 308",
+					Optional:    true,
+					Default:     301,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.IntInSlice([]int{301, 302, 307, 308}),
+					),
+				},
+			},
+		},
+		params: func(m structure) (string, string) {
+			return m.getStr(keyEdgeRuleActionURL), strconv.Itoa(m.getInt(keyEdgeRuleActionStatusCode))
+		},
+		attrs: func(param1, param2 string) map[string]interface{} {
+			return map[string]interface{}{
+				keyEdgeRuleActionURL:        param1,
+				keyEdgeRuleActionStatusCode: atoiOrZero(param2),
+			}
+		},
+	},
+	"origin_url":                 edgeRuleActionURLBlock,
+	"override_cache_time":        edgeRuleActionCacheTimeBlock,
+	"override_cache_time_public": edgeRuleActionCacheTimeBlock,
+	"set_request_header":         edgeRuleActionHeaderBlock,
+	"set_response_header":        edgeRuleActionHeaderBlock,
+	"set_status_code": {
+		resource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				keyEdgeRuleActionStatusCode: {
+					Type:             schema.TypeInt,
+					Description:      "The HTTP status code of the response.",
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(100, 599)),
+				},
+			},
+		},
+		params: func(m structure) (string, string) {
+			return strconv.Itoa(m.getInt(keyEdgeRuleActionStatusCode)), ""
+		},
+		attrs: func(param1, _ string) map[string]interface{} {
+			return map[string]interface{}{keyEdgeRuleActionStatusCode: atoiOrZero(param1)}
+		},
+	},
+}
+
+var edgeRuleActionBlockKeys = func() []string {
+	res := make([]string, 0, len(edgeRuleActionBlocks))
+	for k := range edgeRuleActionBlocks {
+		res = append(res, k)
+	}
+
+	sort.Strings(res)
+
+	return res
+}()
+
+func atoiOrZero(s string) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+// edgeRuleActionSchemas returns the schemas of the typed action blocks.
+func edgeRuleActionSchemas() map[string]*schema.Schema {
+	res := make(map[string]*schema.Schema, len(edgeRuleActionBlocks))
+
+	for key, block := range edgeRuleActionBlocks {
+		res[key] = &schema.Schema{
+			Type: schema.TypeList,
+			Description: fmt.Sprintf("Configures the %q action, alternative to setting %s, %s and %s.",
+				key, keyEdgeRuleActionType, keyEdgeRuleActionParameter1, keyEdgeRuleActionParameter2,
+			),
+			Optional:     true,
+			Computed:     true,
+			MaxItems:     1,
+			Elem:         block.resource,
+			ExactlyOneOf: edgeRuleActionKeys(),
+		}
+	}
+
+	return res
+}
+
+// edgeRuleActionKeys returns the keys of the attributes and blocks that
+// define the action of an Edge Rule.
+func edgeRuleActionKeys() []string {
+	return append([]string{keyEdgeRuleActionType}, edgeRuleActionBlockKeys...)
+}
+
+// edgeRuleActionDiff plans the values of the action attributes that are not
+// configured. If a typed action block is configured, action_type and the
+// action parameters are derived from it. Otherwise the typed block for the
+// action type is derived from the action parameters.
+func edgeRuleActionDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range edgeRuleActionBlockKeys {
+		if !isConfiguredInDiff(d, key) {
+			continue
+		}
+
+		if err := d.SetNew(keyEdgeRuleActionType, key); err != nil {
+			return err
+		}
+
+		if err := edgeRuleActionClearBlocks(d, key); err != nil {
+			return err
+		}
+
+		if !edgeRuleActionBlockKnown(d, key) {
+			if err := d.SetNewComputed(keyEdgeRuleActionParameter1); err != nil {
+				return err
+			}
+			return d.SetNewComputed(keyEdgeRuleActionParameter2)
+		}
+
+		param1, param2 := edgeRuleActionBlocks[key].params(structureFromResource(d, key))
+		if err := d.SetNew(keyEdgeRuleActionParameter1, param1); err != nil {
+			return err
+		}
+
+		return d.SetNew(keyEdgeRuleActionParameter2, param2)
+	}
+
+	// the raw action parameters are used, unset parameters are empty
+	for _, key := range []string{keyEdgeRuleActionParameter1, keyEdgeRuleActionParameter2} {
+		if !isConfiguredInDiff(d, key) {
+			if err := d.SetNew(key, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.NewValueKnown(keyEdgeRuleActionType) {
+		for _, key := range edgeRuleActionBlockKeys {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	actionType := d.Get(keyEdgeRuleActionType).(string)
+	if err := edgeRuleActionClearBlocks(d, actionType); err != nil {
+		return err
+	}
+
+	block, exists := edgeRuleActionBlocks[actionType]
+	if !exists {
+		return nil
+	}
+
+	if !d.NewValueKnown(keyEdgeRuleActionParameter1) || !d.NewValueKnown(keyEdgeRuleActionParameter2) {
+		return d.SetNewComputed(actionType)
+	}
+
+	return d.SetNew(actionType, []map[string]interface{}{
+		block.attrs(d.Get(keyEdgeRuleActionParameter1).(string), d.Get(keyEdgeRuleActionParameter2).(string)),
+	})
+}
+
+// edgeRuleActionBlockKnown returns true if the values of all attributes of
+// the typed action block with the given key are known.
+func edgeRuleActionBlockKnown(d *schema.ResourceDiff, key string) bool {
+	if !d.NewValueKnown(key) {
+		return false
+	}
+
+	for attr := range edgeRuleActionBlocks[key].resource.Schema {
+		if !d.NewValueKnown(key + ".0." + attr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// edgeRuleActionClearBlocks plans all typed action blocks except the one
+// with the key exceptKey to be empty.
+func edgeRuleActionClearBlocks(d *schema.ResourceDiff, exceptKey string) error {
+	for _, key := range edgeRuleActionBlockKeys {
+		if key == exceptKey {
+			continue
+		}
+
+		if err := d.SetNew(key, []map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// edgeRuleActionBlocksToResource sets the typed block of the action type to
+// the action parameters, all other typed blocks are set to empty.
+func edgeRuleActionBlocksToResource(actionType, param1, param2 string, d *schema.ResourceData) error {
+	for _, key := range edgeRuleActionBlockKeys {
+		var v []map[string]interface{}
+		if key == actionType {
+			v = []map[string]interface{}{edgeRuleActionBlocks[key].attrs(param1, param2)}
+		}
+
+		if err := d.Set(key, v); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyEdgeRuleExtraActions          = "action"
+	keyEdgeRuleExtraActionType       = "type"
+	keyEdgeRuleExtraActionParameter1 = "parameter_1"
+	keyEdgeRuleExtraActionParameter2 = "parameter_2"
+)
+
+var resourceEdgeRuleExtraAction = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyEdgeRuleExtraActionType: {
+			Type: schema.TypeString,
+			Description: "The type of the action. Types that are not supported by the provider are read as their number.\nValid values: " +
+				strings.Join(edgeRuleActionTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(edgeRuleActionTypeKeys, false),
+			),
+		},
+		keyEdgeRuleExtraActionParameter1: {
+			Type:        schema.TypeString,
+			Description: "The action parameter 1. The value depends on the action type like for action_parameter_1.",
+			Optional:    true,
+		},
+		keyEdgeRuleExtraActionParameter2: {
+			Type:        schema.TypeString,
+			Description: "The action parameter 2. The value depends on the action type like for action_parameter_2.",
+			Optional:    true,
+		},
+	},
+}
+
+// edgeRuleExtraActionsFromResource returns the additional actions that are
+// configured via action blocks.
+func edgeRuleExtraActionsFromResource(d *schema.ResourceData) ([]*edgeRuleAction, error) {
+	return edgeRuleExtraActionsFromList(d.Get(keyEdgeRuleExtraActions).([]interface{}))
+}
+
+// edgeRuleExtraActionsFromList converts the values of action blocks to
+// additional actions.
+func edgeRuleExtraActionsFromList(actions []interface{}) ([]*edgeRuleAction, error) {
+	res := make([]*edgeRuleAction, 0, len(actions))
+
+	for i, elem := range actions {
+		action := structure(elem.(map[string]interface{}))
+
+		actionType, err := strIntMapGetOrRaw(edgeRuleActionTypesStr, action.getStr(keyEdgeRuleExtraActionType))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d.%s: %w", keyEdgeRuleExtraActions, i, keyEdgeRuleExtraActionType, err)
+		}
+
+		res = append(res, &edgeRuleAction{
+			ActionType:       &actionType,
+			ActionParameter1: ptr.ToString(action.getStr(keyEdgeRuleExtraActionParameter1)),
+			ActionParameter2: ptr.ToString(action.getStr(keyEdgeRuleExtraActionParameter2)),
+		})
+	}
+
+	return res, nil
+}
+
+// edgeRuleExtraActionsToResource sets the action blocks to the additional
+// actions of an Edge Rule.
+func edgeRuleExtraActionsToResource(actions []*edgeRuleAction, d *schema.ResourceData) error {
+	res, err := edgeRuleExtraActionsFlatten(actions)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(keyEdgeRuleExtraActions, res)
+}
+
+// edgeRuleExtraActionsFlatten converts additional actions to the values of
+// action blocks.
+func edgeRuleExtraActionsFlatten(actions []*edgeRuleAction) ([]map[string]interface{}, error) {
+	res := make([]map[string]interface{}, 0, len(actions))
+
+	for i, action := range actions {
+		actionType, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, action.ActionType)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d.%s: %w", keyEdgeRuleExtraActions, i, keyEdgeRuleExtraActionType, err)
+		}
+
+		res = append(res, map[string]interface{}{
+			keyEdgeRuleExtraActionType:       actionType,
+			keyEdgeRuleExtraActionParameter1: ptr.GetString(action.ActionParameter1),
+			keyEdgeRuleExtraActionParameter2: ptr.GetString(action.ActionParameter2),
+		})
+	}
+
+	return res, nil
+}
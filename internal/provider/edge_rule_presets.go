@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+)
+
+const (
+	edgeRulePresetBlockDotfiles     = "block_dotfiles"
+	edgeRulePresetCacheHashedAssets = "cache_hashed_assets"
+	edgeRulePresetSecurityHeaders   = "security_headers"
+	edgeRulePresetSPAFallback       = "spa_fallback"
+	edgeRulePresetWWWRedirect       = "www_redirect"
+)
+
+const (
+	edgeRulePresetDefaultCacheTime  = 31536000
+	edgeRulePresetDefaultHSTSMaxAge = 31536000
+)
+
+// edgeRulePresetDefaultURLPatterns are the URL patterns of the
+// cache_hashed_assets preset if none are configured.
+var edgeRulePresetDefaultURLPatterns = []string{"*/assets/*"}
+
+// edgeRulePresetDefaultStaticExtensions are the file extensions that are not
+// served from the index URL by the spa_fallback preset if none are
+// configured.
+var edgeRulePresetDefaultStaticExtensions = []string{
+	"avif", "css", "gif", "ico", "jpeg", "jpg", "js", "json", "map", "mp4",
+	"pdf", "png", "svg", "txt", "webmanifest", "webp", "woff", "woff2", "xml",
+}
+
+// edgeRulePresetParams are the parameters that are supported per preset.
+var edgeRulePresetParams = map[string][]string{
+	edgeRulePresetBlockDotfiles:     {},
+	edgeRulePresetCacheHashedAssets: {keyEdgeRulePresetCacheTime, keyEdgeRulePresetURLPatterns},
+	edgeRulePresetSecurityHeaders:   {keyEdgeRulePresetContentSecurityPolicy, keyEdgeRulePresetHSTSMaxAge},
+	edgeRulePresetSPAFallback:       {keyEdgeRulePresetIndexURL, keyEdgeRulePresetStaticExtensions},
+	edgeRulePresetWWWRedirect:       {keyEdgeRulePresetDomain},
+}
+
+// edgeRulePresetRequiredParams are the parameters that must be set per
+// preset.
+var edgeRulePresetRequiredParams = map[string][]string{
+	edgeRulePresetSPAFallback: {keyEdgeRulePresetIndexURL},
+	edgeRulePresetWWWRedirect: {keyEdgeRulePresetDomain},
+}
+
+var edgeRulePresetKeys = func() []string {
+	res := make([]string, 0, len(edgeRulePresetParams))
+	for k := range edgeRulePresetParams {
+		res = append(res, k)
+	}
+
+	sort.Strings(res)
+
+	return res
+}()
+
+// edgeRulePresetsOfParam returns the presets that support the parameter, in
+// alphabetical order.
+func edgeRulePresetsOfParam(param string) []string {
+	var res []string
+
+	for _, preset := range edgeRulePresetKeys {
+		for _, p := range edgeRulePresetParams[preset] {
+			if p == param {
+				res = append(res, preset)
+			}
+		}
+	}
+
+	return res
+}
+
+// edgeRulePresetRules returns the Edge Rules that the preset expands to,
+// with the parameters from d.
+func edgeRulePresetRules(preset string, d resourceDataGetter) ([]*addOrUpdateEdgeRuleOptions, error) {
+	switch preset {
+	case edgeRulePresetBlockDotfiles:
+		return []*addOrUpdateEdgeRuleOptions{
+			edgeRulePresetRule(preset, "block requests for dotfiles",
+				bunny.EdgeRuleActionTypeBlockRequest, "", "",
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, "*/.*"),
+				// .well-known is used e.g. for ACME challenges and security.txt
+				edgeRuleURLTrigger(bunny.MatchingTypeNone, "*/.well-known/*"),
+			),
+		}, nil
+
+	case edgeRulePresetCacheHashedAssets:
+		cacheTime := strconv.Itoa(intOrDefault(d.Get(keyEdgeRulePresetCacheTime).(int), edgeRulePresetDefaultCacheTime))
+		patterns := setOfStrOrDefault(d.Get(keyEdgeRulePresetURLPatterns), edgeRulePresetDefaultURLPatterns)
+
+		return []*addOrUpdateEdgeRuleOptions{
+			edgeRulePresetRule(preset, "override cache time",
+				bunny.EdgeRuleActionTypeOverrideCacheTime, cacheTime, "",
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, patterns...),
+			),
+			edgeRulePresetRule(preset, "override browser cache time",
+				edgeRuleActionTypeOverrideBrowserCacheTime, cacheTime, "",
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, patterns...),
+			),
+		}, nil
+
+	case edgeRulePresetSecurityHeaders:
+		maxAge := intOrDefault(d.Get(keyEdgeRulePresetHSTSMaxAge).(int), edgeRulePresetDefaultHSTSMaxAge)
+
+		res := []*addOrUpdateEdgeRuleOptions{
+			edgeRulePresetRule(preset, "Strict-Transport-Security header",
+				bunny.EdgeRuleActionTypeSetResponseHeader,
+				"Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", maxAge),
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, "*"),
+			),
+			edgeRulePresetRule(preset, "X-Content-Type-Options header",
+				bunny.EdgeRuleActionTypeSetResponseHeader,
+				"X-Content-Type-Options", "nosniff",
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, "*"),
+			),
+		}
+
+		if csp := d.Get(keyEdgeRulePresetContentSecurityPolicy).(string); csp != "" {
+			res = append(res, edgeRulePresetRule(preset, "Content-Security-Policy header",
+				bunny.EdgeRuleActionTypeSetResponseHeader,
+				"Content-Security-Policy", csp,
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, "*"),
+			))
+		}
+
+		return res, nil
+
+	case edgeRulePresetSPAFallback:
+		extensions := setOfStrOrDefault(d.Get(keyEdgeRulePresetStaticExtensions), edgeRulePresetDefaultStaticExtensions)
+
+		return []*addOrUpdateEdgeRuleOptions{
+			edgeRulePresetRule(preset, "serve index for application routes",
+				bunny.EdgeRuleActionTypeOriginURL, edgeRulePresetSPAOriginURL(d.Get(keyEdgeRulePresetIndexURL).(string)), "",
+				&bunny.EdgeRuleTrigger{
+					Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURLExtension),
+					PatternMatchingType: ptr.ToInt(bunny.MatchingTypeNone),
+					PatternMatches:      extensions,
+					Parameter1:          ptr.ToString(""),
+				},
+			),
+		}, nil
+
+	case edgeRulePresetWWWRedirect:
+		domain := d.Get(keyEdgeRulePresetDomain).(string)
+
+		return []*addOrUpdateEdgeRuleOptions{
+			edgeRulePresetRule(preset, "redirect www."+domain+" to "+domain,
+				bunny.EdgeRuleActionTypeRedirect, "https://"+domain+"%{Url.Path}", "301",
+				edgeRuleURLTrigger(bunny.MatchingTypeAny, "http://www."+domain+"/*", "https://www."+domain+"/*"),
+			),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported preset: %q", preset)
+}
+
+// edgeRulePresetSPAOriginURL returns the origin URL for the spa_fallback
+// preset. The origin_url action appends the request path to the origin URL,
+// it is therefore passed as query parameter to the index document. A request
+// for /app/route reaches the origin as
+// https://origin.example.com/index.html?path=/app/route.
+func edgeRulePresetSPAOriginURL(indexURL string) string {
+	if strings.Contains(indexURL, "?") {
+		return indexURL + "&path="
+	}
+
+	return indexURL + "?path="
+}
+
+// edgeRulePresetRule returns an enabled Edge Rule with the given action,
+// that is executed when all triggers match.
+func edgeRulePresetRule(
+	preset, description string,
+	actionType int,
+	actionParameter1, actionParameter2 string,
+	triggers ...*bunny.EdgeRuleTrigger,
+) *addOrUpdateEdgeRuleOptions {
+	return &addOrUpdateEdgeRuleOptions{
+		AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+			ActionType:          ptr.ToInt(actionType),
+			ActionParameter1:    ptr.ToString(actionParameter1),
+			ActionParameter2:    ptr.ToString(actionParameter2),
+			Triggers:            triggers,
+			TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+			Description:         ptr.ToString(edgeRulePresetDescriptionPrefix(preset) + description),
+			Enabled:             ptr.ToBool(true),
+		},
+		ExtraActions: []*edgeRuleAction{},
+	}
+}
+
+// edgeRulePresetDescriptionPrefix returns the prefix of the descriptions of
+// the Edge Rules that the preset expands to.
+func edgeRulePresetDescriptionPrefix(preset string) string {
+	return fmt.Sprintf("preset %s: ", preset)
+}
+
+func edgeRuleURLTrigger(patternMatchingType int, patterns ...string) *bunny.EdgeRuleTrigger {
+	return &bunny.EdgeRuleTrigger{
+		Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+		PatternMatchingType: ptr.ToInt(patternMatchingType),
+		PatternMatches:      patterns,
+		Parameter1:          ptr.ToString(""),
+	}
+}
+
+func intOrDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+
+	return v
+}
+
+// setOfStrOrDefault returns the sorted elements of the *schema.Set v or def
+// if the set is empty.
+func setOfStrOrDefault(v interface{}, def []string) []string {
+	res := strSetAsSlice(v)
+	if len(res) == 0 {
+		return def
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// edgeRulePresetDescription returns a description of the Edge Rules that the
+// presets expand to, for the documentation.
+func edgeRulePresetDescription() string {
+	return strings.Join([]string{
+		edgeRulePresetBlockDotfiles + " (blocks requests for paths starting with a dot, except /.well-known/)",
+		edgeRulePresetCacheHashedAssets + " (overrides the cache and browser cache time of URLs matching url_patterns)",
+		edgeRulePresetSecurityHeaders + " (sets the Strict-Transport-Security, X-Content-Type-Options and optionally Content-Security-Policy response headers)",
+		edgeRulePresetSPAFallback + " (requests without one of the static_extensions are served from index_url, the request path is passed as path query parameter)",
+		edgeRulePresetWWWRedirect + " (redirects requests for www.<domain> permanently to https://<domain>, keeping the path)",
+	}, ", ")
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// edgeRulePresetTestParams are valid parameters per preset.
+var edgeRulePresetTestParams = map[string]map[string]interface{}{
+	edgeRulePresetBlockDotfiles:     {},
+	edgeRulePresetCacheHashedAssets: {keyEdgeRulePresetURLPatterns: []interface{}{"*/static/*", "*/assets/*"}},
+	edgeRulePresetSecurityHeaders:   {keyEdgeRulePresetContentSecurityPolicy: "default-src 'self'"},
+	edgeRulePresetSPAFallback:       {keyEdgeRulePresetIndexURL: "https://origin.example.com/index.html"},
+	edgeRulePresetWWWRedirect:       {keyEdgeRulePresetDomain: "example.com"},
+}
+
+func edgeRulePresetTestResourceData(t *testing.T, preset string, params map[string]interface{}) *schema.ResourceData {
+	raw := map[string]interface{}{
+		keyEdgeRulePullZoneID:   1,
+		keyEdgeRulePresetPreset: preset,
+	}
+
+	for k, v := range params {
+		raw[k] = v
+	}
+
+	return schema.TestResourceDataRaw(t, resourceEdgeRulePreset().Schema, raw)
+}
+
+func TestEdgeRulePresetRules(t *testing.T) {
+	descriptions := map[string]struct{}{}
+
+	for _, preset := range edgeRulePresetKeys {
+		t.Run(preset, func(t *testing.T) {
+			params, exists := edgeRulePresetTestParams[preset]
+			if !exists {
+				t.Fatalf("edgeRulePresetTestParams has no entry for the preset")
+			}
+
+			rules, err := edgeRulePresetRules(preset, edgeRulePresetTestResourceData(t, preset, params))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(rules) == 0 {
+				t.Fatal("preset expanded to no edge rules")
+			}
+
+			for i, r := range rules {
+				if _, exists := edgeRuleActionTypesInt[ptr.GetInt(r.ActionType)]; !exists {
+					t.Errorf("rule %d: unsupported action type %d", i, ptr.GetInt(r.ActionType))
+				}
+
+				if len(r.Triggers) == 0 || len(r.Triggers) > 5 {
+					t.Errorf("rule %d: has %d triggers, must have 1-5", i, len(r.Triggers))
+				}
+
+				for _, tr := range r.Triggers {
+					typ, err := intStrMapGet(edgeRuleTriggerTypesInt, tr.Type)
+					if err != nil {
+						t.Errorf("rule %d: %s", i, err)
+						continue
+					}
+
+					validate := edgeRuleTriggerPatternValidators[typ]
+					for _, pattern := range tr.PatternMatches {
+						if validate == nil {
+							break
+						}
+
+						if err := validate(pattern); err != nil {
+							t.Errorf("rule %d: invalid %s pattern: %s", i, typ, err)
+						}
+					}
+				}
+
+				description := ptr.GetString(r.Description)
+				if _, exists := descriptions[description]; exists {
+					t.Errorf("rule %d: description %q is not unique", i, description)
+				}
+				descriptions[description] = struct{}{}
+			}
+		})
+	}
+}
+
+func TestEdgeRulePresetSecurityHeadersCSP(t *testing.T) {
+	withCSP, err := edgeRulePresetRules(edgeRulePresetSecurityHeaders, edgeRulePresetTestResourceData(
+		t, edgeRulePresetSecurityHeaders, edgeRulePresetTestParams[edgeRulePresetSecurityHeaders],
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withoutCSP, err := edgeRulePresetRules(edgeRulePresetSecurityHeaders, edgeRulePresetTestResourceData(
+		t, edgeRulePresetSecurityHeaders, nil,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(withCSP) != len(withoutCSP)+1 {
+		t.Errorf("expected one additional edge rule when content_security_policy is set, got %d with and %d without",
+			len(withCSP), len(withoutCSP))
+	}
+
+	if got := ptr.GetString(withoutCSP[0].ActionParameter2); got != "max-age=31536000; includeSubDomains" {
+		t.Errorf("unexpected default Strict-Transport-Security header value: %q", got)
+	}
+}
+
+func TestEdgeRulePresetSPAFallbackOriginURL(t *testing.T) {
+	testCases := []struct {
+		indexURL string
+		// wantOriginURL is the URL that reaches the origin for a request
+		// for /app/route, the origin_url action appends the request path
+		// to its parameter.
+		wantOriginURL string
+	}{
+		{
+			indexURL:      "https://origin.example.com/index.html",
+			wantOriginURL: "https://origin.example.com/index.html?path=/app/route",
+		},
+		{
+			indexURL:      "https://origin.example.com/index.html?v=2",
+			wantOriginURL: "https://origin.example.com/index.html?v=2&path=/app/route",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.indexURL, func(t *testing.T) {
+			rules, err := edgeRulePresetRules(edgeRulePresetSPAFallback, edgeRulePresetTestResourceData(
+				t, edgeRulePresetSPAFallback, map[string]interface{}{keyEdgeRulePresetIndexURL: tc.indexURL},
+			))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := ptr.GetInt(rules[0].ActionType); got != bunny.EdgeRuleActionTypeOriginURL {
+				t.Fatalf("expected action type origin_url, got %d", got)
+			}
+
+			if got := ptr.GetString(rules[0].ActionParameter1) + "/app/route"; got != tc.wantOriginURL {
+				t.Errorf("expected origin URL %q, got %q", tc.wantOriginURL, got)
+			}
+		})
+	}
+}
+
+func TestEdgeRulePresetsOfParam(t *testing.T) {
+	for _, key := range edgeRulePresetParamKeys {
+		if len(edgeRulePresetsOfParam(key)) == 0 {
+			t.Errorf("parameter %s is not supported by any preset", key)
+		}
+	}
+}
+
+func TestEdgeRulePresetInSync(t *testing.T) {
+	wanted, err := edgeRulePresetRules(edgeRulePresetWWWRedirect, edgeRulePresetTestResourceData(
+		t, edgeRulePresetWWWRedirect, edgeRulePresetTestParams[edgeRulePresetWWWRedirect],
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	er := edgeRuleFromOptions(wanted[0])
+	er.GUID = ptr.ToString("a")
+
+	if !edgeRulePresetInSync([]*edgeRule{er}, []string{"a"}, wanted) {
+		t.Error("unchanged edge rule is reported as not in sync")
+	}
+
+	if edgeRulePresetInSync([]*edgeRule{er}, []string{"b"}, wanted) {
+		t.Error("missing edge rule is reported as in sync")
+	}
+
+	changed := *er
+	changed.ActionParameter2 = ptr.ToString("302")
+	if edgeRulePresetInSync([]*edgeRule{&changed}, []string{"a"}, wanted) {
+		t.Error("changed edge rule is reported as in sync")
+	}
+}
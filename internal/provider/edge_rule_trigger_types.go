@@ -2,19 +2,37 @@ package provider
 
 import bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
 
+// Trigger types that are supported by the bunny.net API but are not defined
+// in the client module.
+const (
+	edgeRuleTriggerTypeCookieValue             = 10
+	edgeRuleTriggerTypeOriginRetryAttemptCount = 12
+)
+
 var edgeRuleTriggerTypesStr = map[string]int{
-	"url":             bunny.EdgeRuleTriggerTypeURL,
-	"request_header":  bunny.EdgeRuleTriggerTypeRequestHeader,
-	"response_header": bunny.EdgeRuleTriggerTypeResponseHeader,
-	"url_extensions":  bunny.EdgeRuleTriggerTypeURLExtension,
-	"country_code":    bunny.EdgeRuleTriggerTypeCountryCode,
-	"remote_ip":       bunny.EdgeRuleTriggerTypeRemoteIP,
-	"query_string":    bunny.EdgeRuleTriggerTypeURLQueryString,
-	"random_chance":   bunny.EdgeRuleTriggerTypeRandomChance,
-	"status_code":     bunny.EdgeRuleTriggerTypeStatusCode,
-	"request_method":  bunny.EdgeRuleTriggerTypeRequestMethod,
+	"url":                        bunny.EdgeRuleTriggerTypeURL,
+	"request_header":             bunny.EdgeRuleTriggerTypeRequestHeader,
+	"response_header":            bunny.EdgeRuleTriggerTypeResponseHeader,
+	"url_extensions":             bunny.EdgeRuleTriggerTypeURLExtension,
+	"country_code":               bunny.EdgeRuleTriggerTypeCountryCode,
+	"remote_ip":                  bunny.EdgeRuleTriggerTypeRemoteIP,
+	"query_string":               bunny.EdgeRuleTriggerTypeURLQueryString,
+	"random_chance":              bunny.EdgeRuleTriggerTypeRandomChance,
+	"status_code":                bunny.EdgeRuleTriggerTypeStatusCode,
+	"request_method":             bunny.EdgeRuleTriggerTypeRequestMethod,
+	"cookie_value":               edgeRuleTriggerTypeCookieValue,
+	"origin_retry_attempt_count": edgeRuleTriggerTypeOriginRetryAttemptCount,
 }
 
 var edgeRuleTriggerTypesInt = reverseStrIntMap(edgeRuleTriggerTypesStr)
 
 var edgeRuleTriggerTypeKeys = strIntMapKeysSorted(edgeRuleTriggerTypesStr)
+
+// edgeRuleTriggerParameter1Docs describes the value of parameter_1 per
+// trigger type, trigger types that are missing have no parameter.
+var edgeRuleTriggerParameter1Docs = map[string]string{
+	"cookie_value":    "cookie name",
+	"query_string":    "query parameter name",
+	"request_header":  "header name",
+	"response_header": "header name",
+}
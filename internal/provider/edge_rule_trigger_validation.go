@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// edgeRuleTriggerPatternValidators contains functions that validate the
+// pattern matches of a trigger, the keys are trigger types.
+var edgeRuleTriggerPatternValidators = map[string]func(pattern string) error{
+	"country_code":               validateEdgeRuleCountryCodePattern,
+	"origin_retry_attempt_count": validateEdgeRuleRetryAttemptCountPattern,
+	"random_chance":              validateEdgeRuleRandomChancePattern,
+	"remote_ip":                  validateEdgeRuleRemoteIPPattern,
+	"request_method":             validateEdgeRuleRequestMethodPattern,
+	"status_code":                validateEdgeRuleStatusCodePattern,
+	"url":                        validateEdgeRuleURLPattern,
+}
+
+// edgeRuleTriggerTypesRequiringParameter1 contains the trigger types for
+// that parameter_1 must be set, it contains the header or query string
+// parameter name.
+var edgeRuleTriggerTypesRequiringParameter1 = map[string]struct{}{
+	"cookie_value":    {},
+	"query_string":    {},
+	"request_header":  {},
+	"response_header": {},
+}
+
+var edgeRuleRequestMethods = map[string]struct{}{
+	http.MethodConnect: {},
+	http.MethodDelete:  {},
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodOptions: {},
+	http.MethodPatch:   {},
+	http.MethodPost:    {},
+	http.MethodPut:     {},
+	http.MethodTrace:   {},
+}
+
+func validateEdgeRuleCountryCodePattern(pattern string) error {
+	if _, exists := isoCountryCodes[strings.ToUpper(pattern)]; !exists {
+		return errors.New("must be an ISO 3166-1 alpha-2 country code")
+	}
+
+	return nil
+}
+
+func validateEdgeRuleRandomChancePattern(pattern string) error {
+	v, err := strconv.Atoi(pattern)
+	if err != nil || v < 0 || v > 100 {
+		return errors.New("must be a percentage between 0 and 100")
+	}
+
+	return nil
+}
+
+func validateEdgeRuleRetryAttemptCountPattern(pattern string) error {
+	v, err := strconv.Atoi(pattern)
+	if err != nil || v < 0 {
+		return errors.New("must be a number greater or equal than 0")
+	}
+
+	return nil
+}
+
+func validateEdgeRuleRemoteIPPattern(pattern string) error {
+	if net.ParseIP(pattern) != nil {
+		return nil
+	}
+
+	if _, _, err := net.ParseCIDR(pattern); err != nil {
+		return errors.New("must be an IP address or a CIDR network")
+	}
+
+	return nil
+}
+
+func validateEdgeRuleRequestMethodPattern(pattern string) error {
+	if _, exists := edgeRuleRequestMethods[strings.ToUpper(pattern)]; !exists {
+		return errors.New("must be an HTTP request method")
+	}
+
+	return nil
+}
+
+func validateEdgeRuleStatusCodePattern(pattern string) error {
+	v, err := strconv.Atoi(pattern)
+	if err != nil || v < 100 || v > 599 {
+		return errors.New("must be an HTTP status code between 100 and 599")
+	}
+
+	return nil
+}
+
+// validateEdgeRuleURLPattern ensures that the pattern is a URL or a path,
+// that can contain "*" as wildcard.
+func validateEdgeRuleURLPattern(pattern string) error {
+	if pattern == "" {
+		return errors.New("must not be empty")
+	}
+
+	if strings.ContainsAny(pattern, " \t\r\n") {
+		return errors.New("must not contain whitespace")
+	}
+
+	for _, prefix := range []string{"*", "/", "http://", "https://"} {
+		if strings.HasPrefix(pattern, prefix) {
+			return nil
+		}
+	}
+
+	return errors.New(`must start with "*", "/", "http://" or "https://"`)
+}
+
+// edgeRuleTriggersValidate validates the pattern matches and parameters of
+// the triggers depending on their type.
+func edgeRuleTriggersValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(keyEdgeRuleTriggers) {
+		return nil
+	}
+
+	var errs attrErrors
+	edgeRuleTriggersValidateSet(&errs, d.Get(keyEdgeRuleTriggers).(*schema.Set), cty.GetAttrPath(keyEdgeRuleTriggers))
+
+	return errs.err()
+}
+
+// edgeRuleTriggersValidateSet validates the elements of a trigger set and
+// adds found issues to errs, path is the path of the set in the resource.
+func edgeRuleTriggersValidateSet(errs *attrErrors, triggers *schema.Set, path cty.Path) {
+	for _, elem := range triggers.List() {
+		trigger := structure(elem.(map[string]interface{}))
+		triggerType := trigger.getStr(keyEdgeRuleTriggerType)
+
+		if _, exists := edgeRuleTriggerTypesRequiringParameter1[triggerType]; exists &&
+			trigger.getStr(keyEdgeRuleTriggerParameter1) == "" {
+			errs.add(path, "%s is required for %s triggers", keyEdgeRuleTriggerParameter1, triggerType)
+		}
+
+		validate, exists := edgeRuleTriggerPatternValidators[triggerType]
+		if !exists {
+			continue
+		}
+
+		patterns, _ := trigger[keyEdgeRuleTriggerPatternMatches].(*schema.Set)
+		if patterns == nil {
+			continue
+		}
+
+		for _, pattern := range strSetAsSlice(patterns) {
+			if err := validate(pattern); err != nil {
+				errs.add(path, "%s %q of %s trigger %s", keyEdgeRuleTriggerPatternMatches, pattern, triggerType, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEdgeRuleTriggerPatternValidators(t *testing.T) {
+	testcases := []struct {
+		triggerType string
+		pattern     string
+		valid       bool
+	}{
+		{triggerType: "country_code", pattern: "DE", valid: true},
+		{triggerType: "country_code", pattern: "us", valid: true},
+		{triggerType: "country_code", pattern: "XX"},
+		{triggerType: "country_code", pattern: "DEU"},
+		{triggerType: "origin_retry_attempt_count", pattern: "0", valid: true},
+		{triggerType: "origin_retry_attempt_count", pattern: "2", valid: true},
+		{triggerType: "origin_retry_attempt_count", pattern: "-1"},
+		{triggerType: "random_chance", pattern: "0", valid: true},
+		{triggerType: "random_chance", pattern: "100", valid: true},
+		{triggerType: "random_chance", pattern: "101"},
+		{triggerType: "random_chance", pattern: "50%"},
+		{triggerType: "remote_ip", pattern: "192.0.2.1", valid: true},
+		{triggerType: "remote_ip", pattern: "2001:db8::/32", valid: true},
+		{triggerType: "remote_ip", pattern: "192.0.2.0/33"},
+		{triggerType: "remote_ip", pattern: "localhost"},
+		{triggerType: "request_method", pattern: "GET", valid: true},
+		{triggerType: "request_method", pattern: "post", valid: true},
+		{triggerType: "request_method", pattern: "FETCH"},
+		{triggerType: "status_code", pattern: "404", valid: true},
+		{triggerType: "status_code", pattern: "99"},
+		{triggerType: "status_code", pattern: "abc"},
+		{triggerType: "url", pattern: "*", valid: true},
+		{triggerType: "url", pattern: "/blog/*", valid: true},
+		{triggerType: "url", pattern: "https://example.com/*", valid: true},
+		{triggerType: "url", pattern: "blog/*"},
+		{triggerType: "url", pattern: "/my blog"},
+		{triggerType: "url", pattern: ""},
+	}
+
+	for _, tc := range testcases {
+		err := edgeRuleTriggerPatternValidators[tc.triggerType](tc.pattern)
+		if tc.valid && err != nil {
+			t.Errorf("%s pattern %q: expected to be valid, got error: %s", tc.triggerType, tc.pattern, err)
+		}
+
+		if !tc.valid && err == nil {
+			t.Errorf("%s pattern %q: expected to be invalid, got no error", tc.triggerType, tc.pattern)
+		}
+	}
+}
+
+func TestEdgeRuleTriggerValidationTypesExist(t *testing.T) {
+	for triggerType := range edgeRuleTriggerPatternValidators {
+		if _, exists := edgeRuleTriggerTypesStr[triggerType]; !exists {
+			t.Errorf("pattern validator is defined for unknown trigger type %q", triggerType)
+		}
+	}
+
+	for triggerType := range edgeRuleTriggerTypesRequiringParameter1 {
+		if _, exists := edgeRuleTriggerTypesStr[triggerType]; !exists {
+			t.Errorf("parameter_1 requirement is defined for unknown trigger type %q", triggerType)
+		}
+	}
+}
+
+func TestEdgeRuleTriggersValidateAttributePath(t *testing.T) {
+	r := resourceEdgeRule()
+	ty := r.CoreConfigSchema().ImpliedType()
+	triggerTy := ty.AttributeType(keyEdgeRuleTriggers).ElementType()
+
+	cfg := testObjectVal(r, ty, map[string]cty.Value{
+		keyEdgeRulePullZoneID: cty.NumberIntVal(1),
+		keyEdgeRuleActionType: cty.StringVal("force_ssl"),
+		keyEdgeRuleTriggers: cty.SetVal([]cty.Value{
+			testObjectVal(resourceEdgeRuleTrigger, triggerTy, map[string]cty.Value{
+				keyEdgeRuleTriggerType:                cty.StringVal("country_code"),
+				keyEdgeRuleTriggerPatternMatchingType: cty.StringVal("any"),
+				keyEdgeRuleTriggerPatternMatches:      cty.SetVal([]cty.Value{cty.StringVal("XX")}),
+			}),
+		}),
+	})
+
+	path := testDiagAttributePath(t, testPlanCreate(t, "bunny_edgerule", cfg))
+
+	want := tftypes.NewAttributePath().WithAttributeName(keyEdgeRuleTriggers)
+	if !path.Equal(want) {
+		t.Errorf("expected the diagnostic for attribute %s, got: %s", want, path)
+	}
+}
@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const keyEdgeRuleWriteRetries = "edge_rule_write_retries"
+
+// pullZoneLocks provides a mutex per Pull Zone.
+type pullZoneLocks struct {
+	mu    sync.Mutex
+	locks map[int64]*sync.Mutex
+}
+
+// lock locks the mutex of the Pull Zone and returns a function that unlocks
+// it.
+func (l *pullZoneLocks) lock(pullZoneID int64) (unlock func()) {
+	l.mu.Lock()
+	m, exists := l.locks[pullZoneID]
+	if !exists {
+		m = &sync.Mutex{}
+		l.locks[pullZoneID] = m
+	}
+	l.mu.Unlock()
+
+	m.Lock()
+
+	return m.Unlock
+}
+
+// edgeRuleLocks serializes write operations on the Edge Rules of a Pull
+// Zone. The edge rule API endpoints are not concurrency safe, if multiple
+// Edge Rules of the same Pull Zone are changed in parallel, changes can get
+// lost. Edge Rules of different Pull Zones are changed in parallel.
+var edgeRuleLocks = pullZoneLocks{locks: map[int64]*sync.Mutex{}}
+
+// edgeRuleWrite is a write operation on an Edge Rule of a Pull Zone.
+type edgeRuleWrite struct {
+	// action describes the write operation in diagnostics, e.g.
+	// "deleting edge rule".
+	action string
+	// guid is the GUID of the written Edge Rule, it is empty if the Edge
+	// Rule is created.
+	guid string
+	// apply sends the write operation to the API.
+	apply func(ctx context.Context) error
+	// applied returns true if edgeRules contain the change.
+	applied func(edgeRules []*edgeRule) bool
+}
+
+// edgeRuleWriteVerified applies w and verifies it by retrieving the Edge
+// Rules of the Pull Zone afterwards. before are the Edge Rules of the Pull
+// Zone before the write, the Pull Zone must be locked via edgeRuleLocks.
+//
+// If the change is missing, it is applied again. If Edge Rules that existed
+// before are missing, except the written one, they are restored. This is
+// repeated until the verification succeeds or the retry budget of the
+// provider is exhausted. Edge Rules that are still missing then might have
+// been deleted on purpose, e.g. via the UI, they are left alone and reported
+// as warning.
+// The retrieved Edge Rules are returned.
+func edgeRuleWriteVerified(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	before []*edgeRule,
+	w *edgeRuleWrite,
+) ([]*edgeRule, diag.Diagnostics) {
+	needsApply := true
+
+	for attempt := 0; ; attempt++ {
+		if needsApply {
+			if err := w.apply(ctx); err != nil {
+				return nil, diagsErrFromErr(w.action+" failed", err)
+			}
+		}
+
+		after, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+		if err != nil {
+			return nil, diagsErrFromErr(w.action+" failed", fmt.Errorf("retrieving edge rules for verification failed: %w", err))
+		}
+
+		needsApply = !w.applied(after)
+		lost := lostEdgeRules(before, after, w.guid)
+
+		if !needsApply && len(lost) == 0 {
+			return after, nil
+		}
+
+		if attempt >= pm.edgeRuleWriteRetries {
+			if needsApply {
+				return nil, diag.Errorf("%s failed, retry budget (%s) exhausted: the change is missing in the edge rules retrieved afterwards",
+					w.action, keyEdgeRuleWriteRetries)
+			}
+
+			return after, edgeRulesLostDiags(pullZoneID, lost)
+		}
+
+		logger.Infof("pull zone %d: verifying edge rule write failed (change missing: %t, lost edge rules: %d), retrying (%d/%d)",
+			pullZoneID, needsApply, len(lost), attempt+1, pm.edgeRuleWriteRetries)
+
+		for _, er := range lost {
+			logger.Infof("pull zone %d: restoring lost edge rule %q", pullZoneID, ptr.GetString(er.GUID))
+
+			// The GUID of the lost Edge Rule does not exist anymore, it
+			// is restored as new Edge Rule.
+			opts := edgeRuleToOptions(er)
+			opts.GUID = nil
+
+			if err := pm.api.edgeRuleAddOrUpdate(ctx, pullZoneID, opts); err != nil {
+				return nil, diagsErrFromErr(w.action+" failed", fmt.Errorf("restoring lost edge rule %q failed: %w", ptr.GetString(er.GUID), err))
+			}
+		}
+	}
+}
+
+// edgeRulesLostDiags returns a warning about the Edge Rules that got lost
+// and could not be restored.
+func edgeRulesLostDiags(pullZoneID int64, lost []*edgeRule) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("edge rules of pull zone %d are missing", pullZoneID),
+		Detail: fmt.Sprintf("The edge rules %s are missing after changing the edge rules of the pull zone and could not be restored within the retry budget (%s). "+
+			"They might have been deleted on purpose, e.g. via the bunny.net dashboard, and are left alone.",
+			strings.Join(edgeRuleGUIDList(lost), ", "), keyEdgeRuleWriteRetries),
+	}}
+}
+
+// edgeRulesDelete deletes the Edge Rules with the guids, Edge Rules that
+// do not exist anymore are ignored. The Pull Zone must be locked via
+// edgeRuleLocks.
+func edgeRulesDelete(ctx context.Context, pm *providerMeta, pullZoneID int64, guids []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, guid := range guids {
+		before, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+		if err != nil {
+			return append(diags, diagsErrFromErr("retrieving pull zone failed", err)...)
+		}
+
+		if edgeRuleByGUID(before, guid) == nil {
+			continue
+		}
+
+		guid := guid
+		_, writeDiags := edgeRuleWriteVerified(ctx, pm, pullZoneID, before, &edgeRuleWrite{
+			action: fmt.Sprintf("deleting edge rule %q", guid),
+			guid:   guid,
+			apply: func(ctx context.Context) error {
+				return pm.client.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid)
+			},
+			applied: func(edgeRules []*edgeRule) bool {
+				return edgeRuleByGUID(edgeRules, guid) == nil
+			},
+		})
+
+		diags = append(diags, writeDiags...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// edgeRuleGUIDList returns the GUIDs of the Edge Rules in their order.
+func edgeRuleGUIDList(edgeRules []*edgeRule) []string {
+	res := make([]string, 0, len(edgeRules))
+	for _, er := range edgeRules {
+		res = append(res, ptr.GetString(er.GUID))
+	}
+
+	return res
+}
+
+// lostEdgeRules returns the Edge Rules in before that are missing in after.
+// The Edge Rule with the GUID exceptGUID is ignored.
+// An Edge Rule that was restored with a different GUID is not considered as
+// missing.
+func lostEdgeRules(before, after []*edgeRule, exceptGUID string) []*edgeRule {
+	afterGUIDs := edgeRuleGUIDs(after)
+	beforeGUIDs := edgeRuleGUIDs(before)
+
+	var res []*edgeRule
+
+	for _, er := range before {
+		guid := ptr.GetString(er.GUID)
+		if guid == exceptGUID {
+			continue
+		}
+
+		if _, exists := afterGUIDs[guid]; exists {
+			continue
+		}
+
+		restored := false
+		for _, a := range after {
+			if _, exists := beforeGUIDs[ptr.GetString(a.GUID)]; !exists && edgeRulesEqual(er, a) {
+				restored = true
+				break
+			}
+		}
+
+		if !restored {
+			res = append(res, er)
+		}
+	}
+
+	return res
+}
+
+// edgeRuleGUIDs returns the GUIDs of the Edge Rules.
+func edgeRuleGUIDs(edgeRules []*edgeRule) map[string]struct{} {
+	res := make(map[string]struct{}, len(edgeRules))
+
+	for _, er := range edgeRules {
+		if er.GUID != nil {
+			res[*er.GUID] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// edgeRuleByGUID returns the Edge Rule with the guid or nil.
+func edgeRuleByGUID(edgeRules []*edgeRule, guid string) *edgeRule {
+	for _, er := range edgeRules {
+		if ptr.GetString(er.GUID) == guid {
+			return er
+		}
+	}
+
+	return nil
+}
+
+// errEdgeRuleCreatedAmbiguous is returned by createdEdgeRuleGUID if
+// multiple new Edge Rules contain the created settings.
+var errEdgeRuleCreatedAmbiguous = errors.New("pull zone has multiple new edge rules with the settings of the created edge rule")
+
+// createdEdgeRules returns the Edge Rules in after, that do not exist in
+// before and contain the settings of opts. Edge Rules with a description
+// marker are ignored, they were claimed by another create operation.
+func createdEdgeRules(before, after []*edgeRule, opts *addOrUpdateEdgeRuleOptions) []*edgeRule {
+	guidsBefore := edgeRuleGUIDs(before)
+
+	var res []*edgeRule
+	for _, er := range after {
+		if er.GUID == nil {
+			continue
+		}
+
+		if _, exists := guidsBefore[*er.GUID]; exists {
+			continue
+		}
+
+		if strings.HasPrefix(ptr.GetString(er.Description), edgeRuleMarkerPrefix) {
+			continue
+		}
+
+		if edgeRuleApplied(er, opts) {
+			res = append(res, er)
+		}
+	}
+
+	return res
+}
+
+// createdEdgeRuleGUID returns the GUID of the Edge Rule in after, that does
+// not exist in before and contains the settings of opts.
+// If multiple such Edge Rules exist, e.g. because the same Edge Rule was
+// created in parallel via the UI, errEdgeRuleCreatedAmbiguous is returned.
+func createdEdgeRuleGUID(before, after []*edgeRule, opts *addOrUpdateEdgeRuleOptions) (string, error) {
+	created := createdEdgeRules(before, after, opts)
+
+	switch len(created) {
+	case 0:
+		return "", errors.New("pull zone has no new edge rule with the settings of the created edge rule")
+	case 1:
+		return *created[0].GUID, nil
+	default:
+		return "", errEdgeRuleCreatedAmbiguous
+	}
+}
+
+// edgeRuleFromOptions returns the Edge Rule that is expected to exist after
+// opts were sent to the API.
+func edgeRuleFromOptions(opts *addOrUpdateEdgeRuleOptions) *edgeRule {
+	return &edgeRule{
+		EdgeRule: bunny.EdgeRule{
+			GUID:                opts.GUID,
+			ActionType:          opts.ActionType,
+			ActionParameter1:    opts.ActionParameter1,
+			ActionParameter2:    opts.ActionParameter2,
+			Triggers:            opts.Triggers,
+			TriggerMatchingType: opts.TriggerMatchingType,
+			Description:         opts.Description,
+			Enabled:             opts.Enabled,
+		},
+		ExtraActions: opts.ExtraActions,
+	}
+}
+
+// edgeRuleToOptions returns the options to create or update er.
+func edgeRuleToOptions(er *edgeRule) *addOrUpdateEdgeRuleOptions {
+	return &addOrUpdateEdgeRuleOptions{
+		AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+			GUID:                er.GUID,
+			ActionType:          er.ActionType,
+			ActionParameter1:    er.ActionParameter1,
+			ActionParameter2:    er.ActionParameter2,
+			Triggers:            er.Triggers,
+			TriggerMatchingType: er.TriggerMatchingType,
+			Description:         er.Description,
+			Enabled:             er.Enabled,
+		},
+		ExtraActions: er.ExtraActions,
+	}
+}
+
+// edgeRulesEqual returns true if the settings of a and b are equal, the
+// GUIDs are not compared. The order of triggers and of their pattern matches
+// is ignored.
+func edgeRulesEqual(a, b *edgeRule) bool {
+	return ptr.GetInt(a.ActionType) == ptr.GetInt(b.ActionType) &&
+		ptr.GetString(a.ActionParameter1) == ptr.GetString(b.ActionParameter1) &&
+		ptr.GetString(a.ActionParameter2) == ptr.GetString(b.ActionParameter2) &&
+		ptr.GetInt(a.TriggerMatchingType) == ptr.GetInt(b.TriggerMatchingType) &&
+		ptr.GetString(a.Description) == ptr.GetString(b.Description) &&
+		ptr.GetBool(a.Enabled) == ptr.GetBool(b.Enabled) &&
+		edgeRuleTriggersKey(a.Triggers) == edgeRuleTriggersKey(b.Triggers) &&
+		edgeRuleActionsKey(a.ExtraActions) == edgeRuleActionsKey(b.ExtraActions)
+}
+
+// edgeRuleApplied returns true if er contains the settings that were sent
+// via opts. It is used to verify writes: only fields that are set in opts
+// are compared and strings are compared via edgeRuleNormalize, because the
+// API might normalize the values it stores.
+func edgeRuleApplied(er *edgeRule, opts *addOrUpdateEdgeRuleOptions) bool {
+	intApplied := func(got, sent *int) bool {
+		return sent == nil || ptr.GetInt(got) == *sent
+	}
+
+	strApplied := func(got, sent *string) bool {
+		return sent == nil || edgeRuleNormalize(ptr.GetString(got)) == edgeRuleNormalize(*sent)
+	}
+
+	return intApplied(er.ActionType, opts.ActionType) &&
+		strApplied(er.ActionParameter1, opts.ActionParameter1) &&
+		strApplied(er.ActionParameter2, opts.ActionParameter2) &&
+		intApplied(er.TriggerMatchingType, opts.TriggerMatchingType) &&
+		strApplied(er.Description, opts.Description) &&
+		(opts.Enabled == nil || ptr.GetBool(er.Enabled) == *opts.Enabled) &&
+		(opts.Triggers == nil || edgeRuleTriggersKeyFunc(er.Triggers, edgeRuleNormalize) == edgeRuleTriggersKeyFunc(opts.Triggers, edgeRuleNormalize)) &&
+		(opts.ExtraActions == nil || edgeRuleActionsKeyFunc(er.ExtraActions, edgeRuleNormalize) == edgeRuleActionsKeyFunc(opts.ExtraActions, edgeRuleNormalize))
+}
+
+// edgeRuleNormalize returns s without surrounding whitespace in lower case.
+func edgeRuleNormalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// edgeRuleTriggersKey returns a string representation of the triggers that
+// does not depend on their order.
+func edgeRuleTriggersKey(triggers []*bunny.EdgeRuleTrigger) string {
+	return edgeRuleTriggersKeyFunc(triggers, func(s string) string { return s })
+}
+
+// edgeRuleTriggersKeyFunc is like edgeRuleTriggersKey, the parameters and
+// pattern matches are passed through normalize.
+func edgeRuleTriggersKeyFunc(triggers []*bunny.EdgeRuleTrigger, normalize func(string) string) string {
+	keys := make([]string, 0, len(triggers))
+
+	for _, t := range triggers {
+		patterns := make([]string, 0, len(t.PatternMatches))
+		for _, p := range t.PatternMatches {
+			patterns = append(patterns, normalize(p))
+		}
+		sort.Strings(patterns)
+
+		keys = append(keys, fmt.Sprintf("%d|%d|%q|%q",
+			ptr.GetInt(t.Type), ptr.GetInt(t.PatternMatchingType), normalize(ptr.GetString(t.Parameter1)), patterns,
+		))
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, "\n")
+}
+
+// edgeRuleActionsKey returns a string representation of the actions.
+func edgeRuleActionsKey(actions []*edgeRuleAction) string {
+	return edgeRuleActionsKeyFunc(actions, func(s string) string { return s })
+}
+
+// edgeRuleActionsKeyFunc is like edgeRuleActionsKey, the parameters are
+// passed through normalize.
+func edgeRuleActionsKeyFunc(actions []*edgeRuleAction, normalize func(string) string) string {
+	keys := make([]string, 0, len(actions))
+
+	for _, a := range actions {
+		keys = append(keys, fmt.Sprintf("%d|%q|%q",
+			ptr.GetInt(a.ActionType), normalize(ptr.GetString(a.ActionParameter1)), normalize(ptr.GetString(a.ActionParameter2)),
+		))
+	}
+
+	return strings.Join(keys, "\n")
+}
@@ -0,0 +1,502 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestPullZoneLocks(t *testing.T) {
+	locks := pullZoneLocks{locks: map[int64]*sync.Mutex{}}
+
+	unlock := locks.lock(1)
+
+	// a different pull zone is not blocked
+	done := make(chan struct{})
+	go func() {
+		locks.lock(2)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("locking pull zone 2 blocked while pull zone 1 was locked")
+	}
+
+	// the same pull zone is blocked until it is unlocked
+	locked := make(chan struct{})
+	go func() {
+		locks.lock(1)()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("locking pull zone 1 succeeded while it was locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("locking pull zone 1 blocked after it was unlocked")
+	}
+}
+
+func testEdgeRule(guid, description string, patterns ...string) *edgeRule {
+	return &edgeRule{
+		EdgeRule: bunny.EdgeRule{
+			GUID:                ptr.ToString(guid),
+			ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeBlockRequest),
+			TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+			Description:         ptr.ToString(description),
+			Enabled:             ptr.ToBool(true),
+			Triggers: []*bunny.EdgeRuleTrigger{
+				{
+					Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+					PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+					PatternMatches:      patterns,
+				},
+			},
+		},
+	}
+}
+
+func TestEdgeRulesEqual(t *testing.T) {
+	a := testEdgeRule("a", "rule", "/a/*", "/b/*")
+
+	if !edgeRulesEqual(a, testEdgeRule("b", "rule", "/b/*", "/a/*")) {
+		t.Error("edge rules with different guids and pattern order are not equal")
+	}
+
+	if edgeRulesEqual(a, testEdgeRule("a", "rule", "/a/*")) {
+		t.Error("edge rules with different pattern matches are equal")
+	}
+
+	if edgeRulesEqual(a, testEdgeRule("a", "other rule", "/a/*", "/b/*")) {
+		t.Error("edge rules with different descriptions are equal")
+	}
+
+	withAction := testEdgeRule("a", "rule", "/a/*", "/b/*")
+	withAction.ExtraActions = []*edgeRuleAction{{ActionType: ptr.ToInt(bunny.EdgeRuleActionTypeForceSSL)}}
+	if edgeRulesEqual(a, withAction) {
+		t.Error("edge rules with different extra actions are equal")
+	}
+}
+
+func TestLostEdgeRules(t *testing.T) {
+	before := []*edgeRule{
+		testEdgeRule("a", "a"),
+		testEdgeRule("b", "b"),
+		testEdgeRule("c", "c"),
+	}
+
+	after := []*edgeRule{
+		testEdgeRule("a", "a"),
+		// b was restored with a new guid
+		testEdgeRule("b2", "b"),
+	}
+
+	lost := lostEdgeRules(before, after, "a")
+	if len(lost) != 1 || ptr.GetString(lost[0].GUID) != "c" {
+		t.Errorf("expected edge rule c to be lost, got: %+v", lost)
+	}
+
+	if lost := lostEdgeRules(before, after, "c"); len(lost) != 0 {
+		t.Errorf("expected no lost edge rules when c is ignored, got: %+v", lost)
+	}
+}
+
+func TestCreatedEdgeRuleGUID(t *testing.T) {
+	before := []*edgeRule{testEdgeRule("a", "a")}
+	opts := edgeRuleToOptions(testEdgeRule("", "rule", "/rule/*"))
+
+	guid, err := createdEdgeRuleGUID(before, append(before, testEdgeRule("b", "rule", "/rule/*")), opts)
+	if err != nil || guid != "b" {
+		t.Errorf("expected guid b, got %q, error: %v", guid, err)
+	}
+
+	guid, err = createdEdgeRuleGUID(before, append(before, testEdgeRule("b", "other", "/other/*"), testEdgeRule("c", "rule", "/rule/*")), opts)
+	if err != nil || guid != "c" {
+		t.Errorf("expected guid c of the edge rule with the created settings, got %q, error: %v", guid, err)
+	}
+
+	_, err = createdEdgeRuleGUID(before, append(before, testEdgeRule("b", "rule", "/rule/*"), testEdgeRule("c", "rule", "/rule/*")), opts)
+	if !errors.Is(err, errEdgeRuleCreatedAmbiguous) {
+		t.Errorf("expected errEdgeRuleCreatedAmbiguous, got: %v", err)
+	}
+
+	// the description is not compared if it is not sent, edge rule b is
+	// ignored nevertheless because it was claimed via a marker
+	noDescription := edgeRuleToOptions(testEdgeRule("", "", "/rule/*"))
+	noDescription.Description = nil
+
+	guid, err = createdEdgeRuleGUID(before, append(before, testEdgeRule("b", newEdgeRuleMarker(), "/rule/*"), testEdgeRule("c", "rule", "/rule/*")), noDescription)
+	if err != nil || guid != "c" {
+		t.Errorf("expected guid c, edge rule b was claimed by another create operation, got %q, error: %v", guid, err)
+	}
+
+	if _, err := createdEdgeRuleGUID(before, before, opts); err == nil {
+		t.Error("expected an error when no edge rule was created")
+	}
+}
+
+func TestEdgeRuleAddVerified(t *testing.T) {
+	testCases := []struct {
+		name       string
+		parallel   *edgeRule
+		wantWrites int
+	}{
+		{
+			name:       "edge rule with other settings created in parallel",
+			parallel:   testEdgeRule("parallel", "other", "/other/*"),
+			wantWrites: 1,
+		},
+		{
+			name:       "edge rule with the same settings created in parallel",
+			parallel:   testEdgeRule("parallel", "rule", "/rule/*"),
+			wantWrites: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := []*edgeRule{testEdgeRule("a", "a")}
+			fake := &fakeEdgeRuleAPI{edgeRules: []*edgeRule{testEdgeRule("a", "a"), tc.parallel}}
+			pm := fake.providerMeta(t, 2)
+
+			guid, after, diags := edgeRuleAddVerified(context.Background(), pm, 1, before, edgeRuleToOptions(testEdgeRule("", "rule", "/rule/*")))
+			if diags.HasError() {
+				t.Fatalf("expected no error, got: %+v", diags)
+			}
+
+			if guid != "new-1" {
+				t.Errorf("expected the guid of the added edge rule, got %q", guid)
+			}
+
+			if er := edgeRuleByGUID(after, guid); er == nil || ptr.GetString(er.Description) != "rule" {
+				t.Errorf("expected the added edge rule with the configured description, got: %+v", er)
+			}
+
+			if fake.writes != tc.wantWrites {
+				t.Errorf("expected %d write requests, got %d", tc.wantWrites, fake.writes)
+			}
+		})
+	}
+}
+
+// fakeEdgeRuleAPI serves the Get Pull Zone, Add/Update Edge Rule, Delete
+// Edge Rule and Set Edge Rule Enabled endpoints for the pull zone with ID 1.
+// The first dropWrites Add/Update requests are answered successfully but
+// are not applied, like it happens when the API loses an update. The first
+// loseOthers Add/Update requests that are applied remove another Edge Rule,
+// like it happens when the API loses a concurrent update. Add/Update
+// requests for Edge Rules with a description in ignoreDescriptions are
+// answered successfully but are not applied.
+// If normalize is true, descriptions and pattern matches are stored without
+// surrounding whitespace and in lower case.
+type fakeEdgeRuleAPI struct {
+	mu                 sync.Mutex
+	edgeRules          []*edgeRule
+	dropWrites         int
+	loseOthers         int
+	ignoreDescriptions []string
+	normalize          bool
+	writes             int
+	deletes            int
+	setEnabled         int
+}
+
+// providerMeta starts a server for the fake API and returns a providerMeta
+// whose API clients send their requests to it.
+func (f *fakeEdgeRuleAPI) providerMeta(t *testing.T, retries int) *providerMeta {
+	t.Helper()
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	srvURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bunny client library does not support changing its base URL,
+	// its requests are redirected to the server by the default transport.
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = srvURL.Scheme
+		req.URL.Host = srvURL.Host
+
+		return defaultTransport.RoundTrip(req)
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	return &providerMeta{
+		client:               bunny.NewClient("key"),
+		api:                  newBunnyAPIClient("key", "test"),
+		edgeRuleWriteRetries: retries,
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (f *fakeEdgeRuleAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/pullzone/1":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"EdgeRules": f.edgeRules})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/pullzone/1/edgerules/addOrUpdate":
+		var opts addOrUpdateEdgeRuleOptions
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		f.writes++
+		if f.dropWrites > 0 {
+			f.dropWrites--
+			return
+		}
+
+		for _, desc := range f.ignoreDescriptions {
+			if ptr.GetString(opts.Description) == desc {
+				return
+			}
+		}
+
+		er := edgeRuleFromOptions(&opts)
+		if er.GUID == nil || *er.GUID == "" {
+			er.GUID = ptr.ToString(fmt.Sprintf("new-%d", f.writes))
+		}
+
+		if f.normalize {
+			er.Description = ptr.ToString(edgeRuleNormalize(ptr.GetString(er.Description)))
+			for _, tr := range er.Triggers {
+				for i, p := range tr.PatternMatches {
+					tr.PatternMatches[i] = edgeRuleNormalize(p)
+				}
+			}
+		}
+
+		if f.loseOthers > 0 {
+			for i, existing := range f.edgeRules {
+				if ptr.GetString(existing.GUID) != ptr.GetString(er.GUID) {
+					f.loseOthers--
+					f.edgeRules = append(f.edgeRules[:i], f.edgeRules[i+1:]...)
+					break
+				}
+			}
+		}
+
+		for i, existing := range f.edgeRules {
+			if ptr.GetString(existing.GUID) == ptr.GetString(opts.GUID) {
+				f.edgeRules[i] = er
+				return
+			}
+		}
+
+		f.edgeRules = append(f.edgeRules, er)
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/pullzone/1/edgerules/"):
+		guid := strings.TrimPrefix(r.URL.Path, "/pullzone/1/edgerules/")
+
+		for i, er := range f.edgeRules {
+			if ptr.GetString(er.GUID) == guid {
+				f.deletes++
+				f.edgeRules = append(f.edgeRules[:i], f.edgeRules[i+1:]...)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/setEdgeRuleEnabled"):
+		guid := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/pullzone/1/edgerules/"), "/setEdgeRuleEnabled")
+
+		var opts bunny.SetEdgeRuleEnabledOptions
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		er := edgeRuleByGUID(f.edgeRules, guid)
+		if er == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		f.setEnabled++
+		er.Enabled = opts.Value
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestEdgeRuleUpdateVerified(t *testing.T) {
+	testcases := []struct {
+		name       string
+		dropWrites int
+		retries    int
+		wantErr    bool
+		wantWrites int
+	}{
+		{name: "no lost writes", retries: 2, wantWrites: 1},
+		{name: "lost write is retried", dropWrites: 2, retries: 2, wantWrites: 3},
+		{name: "retry budget exhausted", dropWrites: 3, retries: 2, wantErr: true, wantWrites: 3},
+		{name: "no retries", dropWrites: 1, retries: 0, wantErr: true, wantWrites: 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeEdgeRuleAPI{
+				edgeRules:  []*edgeRule{testEdgeRule("a", "a")},
+				dropWrites: tc.dropWrites,
+			}
+
+			pm := fake.providerMeta(t, tc.retries)
+			opts := edgeRuleToOptions(testEdgeRule("a", "changed"))
+
+			_, diags := edgeRuleUpdateVerified(context.Background(), pm, 1, fake.edgeRules, opts)
+			err := errFromDiags(diags)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "retry budget") {
+					t.Errorf("expected retry budget error, got: %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+
+			if fake.writes != tc.wantWrites {
+				t.Errorf("expected %d write requests, got %d", tc.wantWrites, fake.writes)
+			}
+		})
+	}
+}
+
+func TestEdgeRuleUpdateVerifiedNormalizedValues(t *testing.T) {
+	fake := &fakeEdgeRuleAPI{
+		edgeRules: []*edgeRule{testEdgeRule("a", "a", "/a/*")},
+		normalize: true,
+	}
+	pm := fake.providerMeta(t, 2)
+
+	opts := edgeRuleToOptions(testEdgeRule("a", " Changed ", "/A/*"))
+
+	if _, diags := edgeRuleUpdateVerified(context.Background(), pm, 1, fake.edgeRules, opts); diags.HasError() {
+		t.Fatalf("expected no error, got: %+v", diags)
+	}
+
+	if fake.writes != 1 {
+		t.Errorf("expected the normalized write to be verified without retries, got %d write requests", fake.writes)
+	}
+}
+
+func TestEdgeRuleWriteRestoresLostEdgeRules(t *testing.T) {
+	fake := &fakeEdgeRuleAPI{
+		edgeRules:  []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")},
+		loseOthers: 1,
+	}
+	pm := fake.providerMeta(t, 2)
+
+	before := []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")}
+
+	after, diags := edgeRuleUpdateVerified(context.Background(), pm, 1, before, edgeRuleToOptions(testEdgeRule("a", "changed")))
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got: %+v", diags)
+	}
+
+	if fake.writes != 2 {
+		t.Errorf("expected the own write and the restore of b, got %d write requests", fake.writes)
+	}
+
+	if len(after) != 2 || ptr.GetString(after[1].Description) != "b" {
+		t.Errorf("expected edge rule b to be restored, got: %+v", after)
+	}
+}
+
+func TestEdgeRuleWriteWarnsAboutDeletedEdgeRules(t *testing.T) {
+	// b was deleted on purpose, restoring it has no effect
+	fake := &fakeEdgeRuleAPI{
+		edgeRules:          []*edgeRule{testEdgeRule("a", "a")},
+		ignoreDescriptions: []string{"b"},
+	}
+	pm := fake.providerMeta(t, 2)
+
+	before := []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")}
+
+	after, diags := edgeRuleUpdateVerified(context.Background(), pm, 1, before, edgeRuleToOptions(testEdgeRule("a", "changed")))
+	if diags.HasError() {
+		t.Fatalf("expected no error, got: %+v", diags)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "b") {
+		t.Errorf("expected a warning about the missing edge rule b, got: %+v", diags)
+	}
+
+	if len(after) != 1 || ptr.GetString(after[0].Description) != "changed" {
+		t.Errorf("expected only the changed edge rule a, got: %+v", after)
+	}
+
+	if fake.writes != 3 {
+		t.Errorf("expected the own write and 2 restore attempts, got %d write requests", fake.writes)
+	}
+}
+
+func TestEdgeRulesDeleteIgnoresMissing(t *testing.T) {
+	fake := &fakeEdgeRuleAPI{edgeRules: []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")}}
+	pm := fake.providerMeta(t, 0)
+
+	if diags := edgeRulesDelete(context.Background(), pm, 1, []string{"a", "gone"}); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got: %+v", diags)
+	}
+
+	if fake.deletes != 1 {
+		t.Errorf("expected 1 delete request, got %d", fake.deletes)
+	}
+
+	if len(fake.edgeRules) != 1 || ptr.GetString(fake.edgeRules[0].GUID) != "b" {
+		t.Errorf("expected only edge rule b to remain, got: %+v", fake.edgeRules)
+	}
+}
+
+func TestEdgeRuleApplied(t *testing.T) {
+	er := testEdgeRule("a", "My Rule", "/Assets/*")
+
+	if !edgeRuleApplied(er, edgeRuleToOptions(testEdgeRule("a", " my rule", "/assets/*"))) {
+		t.Error("edge rule with normalized values is not reported as applied")
+	}
+
+	if edgeRuleApplied(er, edgeRuleToOptions(testEdgeRule("a", "My Rule", "/other/*"))) {
+		t.Error("edge rule with different pattern matches is reported as applied")
+	}
+
+	onlyEnabled := &addOrUpdateEdgeRuleOptions{}
+	onlyEnabled.Enabled = ptr.ToBool(true)
+	if !edgeRuleApplied(er, onlyEnabled) {
+		t.Error("fields that were not written are compared")
+	}
+}
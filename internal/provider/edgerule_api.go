@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+)
+
+// edgeRuleAction is an additional action of an Edge Rule, it is executed
+// after the action defined by the ActionType field of the Edge Rule.
+type edgeRuleAction struct {
+	ActionType       *int    `json:"ActionType,omitempty"`
+	ActionParameter1 *string `json:"ActionParameter1,omitempty"`
+	ActionParameter2 *string `json:"ActionParameter2,omitempty"`
+}
+
+// edgeRule extends bunny.EdgeRule with fields that are returned by the Get
+// Pull Zone API endpoint but are not supported by the bunny client library.
+type edgeRule struct {
+	bunny.EdgeRule
+
+	ExtraActions []*edgeRuleAction `json:"ExtraActions,omitempty"`
+}
+
+// addOrUpdateEdgeRuleOptions extends bunny.AddOrUpdateEdgeRuleOptions with
+// fields that are accepted by the Add/Update Edge Rule API endpoint but are
+// not supported by the bunny client library.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addedgerule
+type addOrUpdateEdgeRuleOptions struct {
+	bunny.AddOrUpdateEdgeRuleOptions
+
+	// ExtraActions is always sent, an empty slice removes all additional
+	// actions of the Edge Rule.
+	ExtraActions []*edgeRuleAction `json:"ExtraActions"`
+}
+
+// edgeRuleAddOrUpdate adds an Edge Rule to the Pull Zone or updates it, if
+// opts.GUID is set.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addedgerule
+func (c *apiClient) edgeRuleAddOrUpdate(ctx context.Context, pullZoneID int64, opts *addOrUpdateEdgeRuleOptions) error {
+	if opts.ExtraActions == nil {
+		opts.ExtraActions = []*edgeRuleAction{}
+	}
+
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/edgerules/addOrUpdate", pullZoneID), opts, nil)
+}
+
+// pullZoneEdgeRules retrieves the Edge Rules of the Pull Zone with the given
+// id.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_index2
+func (c *apiClient) pullZoneEdgeRules(ctx context.Context, pullZoneID int64) ([]*edgeRule, error) {
+	var pz struct {
+		EdgeRules []*edgeRule `json:"EdgeRules"`
+	}
+
+	if err := c.get(ctx, fmt.Sprintf("pullzone/%d", pullZoneID), &pz); err != nil {
+		return nil, err
+	}
+
+	return pz.EdgeRules, nil
+}
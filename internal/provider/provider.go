@@ -4,15 +4,37 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const userAgent = "terraform-provider-bunny"
 const envVarAPIKey = "BUNNY_API_KEY"
 const keyAPIKey = "api_key"
+const keyMaxMonthlyCharges = "max_monthly_charges"
+
+// providerMeta is the value that is passed as meta argument to the CRUD
+// functions of the resources.
+type providerMeta struct {
+	client *bunny.Client
+	api    *apiClient
+
+	// maxMonthlyCharges is the maximum of month-to-date charges a Pull
+	// Zone can have to be planned. 0 means unlimited.
+	maxMonthlyCharges float64
+
+	// onCreateFailure defines what happens with resources that were
+	// created but could not be configured completely.
+	onCreateFailure string
+
+	// edgeRuleWriteRetries is the number of times a write operation on
+	// Edge Rules is retried when its verification fails.
+	edgeRuleWriteRetries int
+}
 
 func init() {
 	// Set descriptions to support markdown syntax, this will be used in document generation
@@ -31,12 +53,43 @@ func New() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc(envVarAPIKey, ""),
 				Description: "The bunny.net API Key.",
 			},
+			keyMaxMonthlyCharges: {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				Description:      "If set, planning changes for a Pull Zone fails when its month-to-date charges exceed the value. 0 disables the check.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+			},
+			keyEdgeRuleWriteRetries: {
+				Type: schema.TypeInt,
+				Description: "The number of times changes of Edge Rules are retried. " +
+					"The Edge Rules of a Pull Zone are retrieved after each change, the change and other Edge Rules that got lost are written again. " +
+					"This can happen when Edge Rules of the same Pull Zone are changed in parallel, e.g. via the UI.",
+				Optional:         true,
+				Default:          3,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			keyOnCreateFailure: {
+				Type: schema.TypeString,
+				Description: "Defines what happens with Pull Zones, Storage Zones, Hostnames and Edge Rules that were created but could not be configured completely. " +
+					"`" + onCreateFailureKeep + "` keeps them in the state and marks them as tainted, they are replaced on the next apply. " +
+					"`" + onCreateFailureDelete + "` deletes them.\nValid values: " + strings.Join(onCreateFailureValues, ", "),
+				Optional:         true,
+				Default:          onCreateFailureKeep,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(onCreateFailureValues, false)),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"bunny_pullzone":    resourcePullZone(),
-			"bunny_edgerule":    resourceEdgeRule(),
-			"bunny_hostname":    resourceHostname(),
-			"bunny_storagezone": resourceStorageZone(),
+			"bunny_api_request":     resourceAPIRequest(),
+			"bunny_pullzone":        resourcePullZone(),
+			"bunny_edgerule":        resourceEdgeRule(),
+			"bunny_edgerules":       resourceEdgeRules(),
+			"bunny_edgerule_preset": resourceEdgeRulePreset(),
+			"bunny_redirect_map":    resourceRedirectMap(),
+			"bunny_hostname":        resourceHostname(),
+			"bunny_storagezone":     resourceStorageZone(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"bunny_api_request": dataSourceAPIRequest(),
 		},
 		ConfigureContextFunc: newProvider,
 	}
@@ -57,10 +110,16 @@ func newProvider(_ context.Context, d *schema.ResourceData) (interface{}, diag.D
 	}
 
 	log.SetFlags(0)
-	return bunny.NewClient(
-		apiKey,
-		bunny.WithUserAgent(ua),
-		bunny.WithHTTPRequestLogger(logger.Debugf),
-		bunny.WithHTTPResponseLogger(logger.Debugf),
-	), nil
+	return &providerMeta{
+		client: bunny.NewClient(
+			apiKey,
+			bunny.WithUserAgent(ua),
+			bunny.WithHTTPRequestLogger(logger.Debugf),
+			bunny.WithHTTPResponseLogger(logger.Debugf),
+		),
+		api:                  newBunnyAPIClient(apiKey, ua),
+		maxMonthlyCharges:    d.Get(keyMaxMonthlyCharges).(float64),
+		onCreateFailure:      d.Get(keyOnCreateFailure).(string),
+		edgeRuleWriteRetries: d.Get(keyEdgeRuleWriteRetries).(int),
+	}, nil
 }
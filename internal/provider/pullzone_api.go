@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+)
+
+// pullZone extends bunny.PullZone with fields that are returned by the Get
+// Pull Zone API endpoint but are not supported by the bunny client library.
+type pullZone struct {
+	bunny.PullZone
+
+	EdgeScriptID     *int64                    `json:"EdgeScriptId,omitempty"`
+	OptimizerClasses []*pullZoneOptimizerClass `json:"OptimizerClasses,omitempty"`
+	Suspended        *bool                     `json:"Suspended,omitempty"`
+
+	// raw contains the unmarshaled JSON object that was received from the
+	// API.
+	raw map[string]interface{}
+}
+
+// unmarshalPullZone parses the JSON encoded Pull Zone in buf.
+func unmarshalPullZone(buf []byte) (*pullZone, error) {
+	var res pullZone
+
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return nil, fmt.Errorf("could not parse response body as pull zone: %w", err)
+	}
+
+	if err := json.Unmarshal(buf, &res.raw); err != nil {
+		return nil, fmt.Errorf("could not parse response body as JSON object: %w", err)
+	}
+
+	return &res, nil
+}
+
+// pullZoneOptimizerClass is an image class of the Bunny Optimizer.
+// Properties contains the image manipulation parameters of the class, the
+// values are strings also for numeric parameters.
+type pullZoneOptimizerClass struct {
+	Name       string            `json:"Name"`
+	Properties map[string]string `json:"Properties"`
+}
+
+// pullZoneUpdateOptions extends bunny.PullZoneUpdateOptions with fields that
+// are accepted by the Update Pull Zone API endpoint but are not supported by
+// the bunny client library.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_updatepullzone
+type pullZoneUpdateOptions struct {
+	bunny.PullZoneUpdateOptions
+
+	EdgeScriptID                *int64  `json:"EdgeScriptId,omitempty"`
+	EnableAutoSSL               *bool   `json:"EnableAutoSSL,omitempty"`
+	OptimizerForceClasses       *bool   `json:"OptimizerForceClasses,omitempty"`
+	OriginHostHeader            *string `json:"OriginHostHeader,omitempty"`
+	OriginType                  *int32  `json:"OriginType,omitempty"`
+	ShieldDDosProtectionEnabled *bool   `json:"ShieldDDosProtectionEnabled,omitempty"`
+	ShieldDDosProtectionType    *int    `json:"ShieldDDosProtectionType,omitempty"`
+	StorageZoneID               *int64  `json:"StorageZoneId,omitempty"`
+
+	// OptimizerClasses is a pointer to be able to distinguish between
+	// not changing the classes (nil) and removing all classes (empty
+	// slice).
+	OptimizerClasses *[]*pullZoneOptimizerClass `json:"OptimizerClasses,omitempty"`
+
+	// extraSettings are merged into the JSON object of the other fields
+	// when the options are marshaled.
+	extraSettings map[string]interface{}
+}
+
+// MarshalJSON returns the JSON encoding of the options, with the extra
+// settings deep-merged into it.
+func (o *pullZoneUpdateOptions) MarshalJSON() ([]byte, error) {
+	// opts has the same fields but not the methods of
+	// pullZoneUpdateOptions, to prevent that json.Marshal calls MarshalJSON
+	// recursively
+	type opts pullZoneUpdateOptions
+
+	buf, err := json.Marshal((*opts)(o))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.extraSettings) == 0 {
+		return buf, nil
+	}
+
+	var res map[string]interface{}
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return nil, err
+	}
+
+	mergeJSONObjects(res, o.extraSettings)
+
+	return json.Marshal(res)
+}
+
+// pullZoneGet retrieves the Pull Zone with the given id.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_index2
+func (c *apiClient) pullZoneGet(ctx context.Context, id int64) (*pullZone, error) {
+	buf, err := c.doRaw(ctx, http.MethodGet, fmt.Sprintf("pullzone/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPullZone(buf)
+}
+
+// pullZoneUpdate changes the configuration of the Pull Zone with the given
+// id and returns the updated Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_updatepullzone
+func (c *apiClient) pullZoneUpdate(ctx context.Context, id int64, opts *pullZoneUpdateOptions) (*pullZone, error) {
+	buf, err := c.doRaw(ctx, http.MethodPost, fmt.Sprintf("pullzone/%d", id), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPullZone(buf)
+}
+
+// pullZoneAddBlockedReferrer adds a hostname to the list of blocked referrers
+// of the Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addblockedreferrer
+func (c *apiClient) pullZoneAddBlockedReferrer(ctx context.Context, id int64, hostname string) error {
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/addBlockedReferrer", id), &pullZoneBlockedReferrerOptions{hostname}, nil)
+}
+
+// pullZoneRemoveBlockedReferrer removes a hostname from the list of blocked
+// referrers of the Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_removeblockedreferrer
+func (c *apiClient) pullZoneRemoveBlockedReferrer(ctx context.Context, id int64, hostname string) error {
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/removeBlockedReferrer", id), &pullZoneBlockedReferrerOptions{hostname}, nil)
+}
+
+type pullZoneBlockedReferrerOptions struct {
+	BlockedHostname string `json:"BlockedHostname"`
+}
+
+// pullZoneAddBlockedIP adds an IP to the list of blocked IPs of the Pull
+// Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_addblockedip
+func (c *apiClient) pullZoneAddBlockedIP(ctx context.Context, id int64, ip string) error {
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/addBlockedIp", id), &pullZoneBlockedIPOptions{ip}, nil)
+}
+
+// pullZoneRemoveBlockedIP removes an IP from the list of blocked IPs of the
+// Pull Zone.
+//
+// Bunny.net API docs: https://docs.bunny.net/reference/pullzonepublic_removeblockedip
+func (c *apiClient) pullZoneRemoveBlockedIP(ctx context.Context, id int64, ip string) error {
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/removeBlockedIp", id), &pullZoneBlockedIPOptions{ip}, nil)
+}
+
+type pullZoneBlockedIPOptions struct {
+	BlockedIP string `json:"BlockedIp"`
+}
+
+// pullZoneSetEnabled enables or disables the Pull Zone.
+// A disabled Pull Zone does not serve any requests.
+//
+// Bunny.net API docs (Pull Zone API reference): https://docs.bunny.net/reference/pullzonepublic_index
+func (c *apiClient) pullZoneSetEnabled(ctx context.Context, id int64, enabled bool) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	return c.post(ctx, fmt.Sprintf("pullzone/%d/%s", id, action), nil, nil)
+}
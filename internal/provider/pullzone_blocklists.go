@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// blockListUpdater applies the changes of a TypeSet field of a Pull Zone
+// that can not be set via the Update Pull Zone endpoint and must be changed
+// entry-wise via add and remove API endpoints.
+type blockListUpdater struct {
+	key    string
+	add    func(ctx context.Context, id int64, entry string) error
+	remove func(ctx context.Context, id int64, entry string) error
+}
+
+func pullZoneBlockListUpdaters(clt *apiClient) []*blockListUpdater {
+	return []*blockListUpdater{
+		{
+			key:    keyBlockedReferrers,
+			add:    clt.pullZoneAddBlockedReferrer,
+			remove: clt.pullZoneRemoveBlockedReferrer,
+		},
+		{
+			key:    keyBlockedIPs,
+			add:    clt.pullZoneAddBlockedIP,
+			remove: clt.pullZoneRemoveBlockedIP,
+		},
+	}
+}
+
+// update removes the entries that are only in the old value of the field and
+// adds the entries that are only in the new value.
+func (u *blockListUpdater) update(ctx context.Context, d *schema.ResourceData, id int64) error {
+	if !d.HasChange(u.key) {
+		return nil
+	}
+
+	oldVal, newVal := d.GetChange(u.key)
+	toRemove, toAdd := strSetDifference(oldVal.(*schema.Set), newVal.(*schema.Set))
+
+	for _, entry := range toRemove {
+		logger.Debugf("pull zone %d: removing %q from %s", id, entry, u.key)
+		if err := u.remove(ctx, id, entry); err != nil {
+			return fmt.Errorf("removing %q from %s failed: %w", entry, u.key, err)
+		}
+	}
+
+	for _, entry := range toAdd {
+		logger.Debugf("pull zone %d: adding %q to %s", id, entry, u.key)
+		if err := u.add(ctx, id, entry); err != nil {
+			return fmt.Errorf("adding %q to %s failed: %w", entry, u.key, err)
+		}
+	}
+
+	return nil
+}
+
+// strSetDifference returns the sorted elements that are only in oldSet and
+// the sorted elements that are only in newSet.
+func strSetDifference(oldSet, newSet *schema.Set) (onlyOld, onlyNew []string) {
+	onlyOld = strSetAsSlice(oldSet.Difference(newSet))
+	onlyNew = strSetAsSlice(newSet.Difference(oldSet))
+
+	sort.Strings(onlyOld)
+	sort.Strings(onlyNew)
+
+	return onlyOld, onlyNew
+}
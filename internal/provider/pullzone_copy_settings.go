@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyCopySettingsFrom = "copy_settings_from"
+	keyCopyEdgeRules    = "copy_edge_rules"
+	keyCopiedSettings   = "copied_settings"
+)
+
+// copiedSettingEdgeRules is the entry in copied_settings that is listed when
+// the edge rules of the template Pull Zone are copied.
+const copiedSettingEdgeRules = "edge_rules"
+
+// pullZoneCopyableBlocks are the blocks of the Pull Zone resource that are
+// copied from the template Pull Zone when they are not configured.
+var pullZoneCopyableBlocks = []string{
+	keyHeaders,
+	keyLimits,
+	keyOptimizer,
+	keySafeHop,
+}
+
+// diffSuppressAfterCreate suppresses differences of attributes that are only
+// evaluated when the resource is created.
+func diffSuppressAfterCreate(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
+}
+
+var resourcePullZoneCopySettingsSchema = map[string]*schema.Schema{
+	keyCopySettingsFrom: {
+		Type: schema.TypeInt,
+		Description: "The ID of a Pull Zone that is used as template when the Pull Zone is created. " +
+			"The settings of the `" + keyHeaders + "`, `" + keyLimits + "`, `" + keyOptimizer + "` and `" + keySafeHop +
+			"` blocks that are not configured are copied from it. Changes are ignored after the Pull Zone was created.",
+		Optional:         true,
+		DiffSuppressFunc: diffSuppressAfterCreate,
+	},
+	keyCopyEdgeRules: {
+		Type:             schema.TypeBool,
+		Description:      "Determines if the edge rules of the `" + keyCopySettingsFrom + "` Pull Zone are copied when the Pull Zone is created.",
+		Optional:         true,
+		Default:          false,
+		RequiredWith:     []string{keyCopySettingsFrom},
+		DiffSuppressFunc: diffSuppressAfterCreate,
+	},
+	keyCopiedSettings: {
+		Type:        schema.TypeList,
+		Description: "The blocks that were copied from the `" + keyCopySettingsFrom + "` Pull Zone when the Pull Zone was created.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+// copySettingsDiff sets the blocks that are not configured to the values of
+// the template Pull Zone when a Pull Zone is created. The copied blocks are
+// listed in copied_settings, to show in the plan which values come from the
+// template.
+func copySettingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	// the template is only known during apply when it is created in the
+	// same run, the copied values are then computed
+	if !d.NewValueKnown(keyCopySettingsFrom) {
+		for _, key := range pullZoneCopyableBlocks {
+			if isConfiguredInDiff(d, key) {
+				continue
+			}
+
+			if err := d.SetNewComputed(key); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+
+		return d.SetNewComputed(keyCopiedSettings)
+	}
+
+	templateID := int64(d.Get(keyCopySettingsFrom).(int))
+	pm, ok := meta.(*providerMeta)
+	if !ok || templateID == 0 {
+		return d.SetNew(keyCopiedSettings, []string{})
+	}
+
+	template, err := pm.api.pullZoneGet(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("%s: retrieving pull zone %d failed: %w", keyCopySettingsFrom, templateID, err)
+	}
+
+	values := resourcePullZone().Data(nil)
+	if err := pullZoneCopyableBlocksToResource(template, values); err != nil {
+		return fmt.Errorf("%s: converting pull zone %d to resource data failed: %w", keyCopySettingsFrom, templateID, err)
+	}
+
+	copied := []string{}
+	for _, key := range pullZoneCopyableBlocks {
+		if isConfiguredInDiff(d, key) {
+			continue
+		}
+
+		if err := d.SetNew(key, values.Get(key)); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		copied = append(copied, key)
+	}
+
+	if d.Get(keyCopyEdgeRules).(bool) {
+		copied = append(copied, copiedSettingEdgeRules)
+	}
+
+	return d.SetNew(keyCopiedSettings, copied)
+}
+
+// pullZoneCopyableBlocksToResource sets the blocks in pullZoneCopyableBlocks
+// in d to the values in pz.
+func pullZoneCopyableBlocksToResource(pz *pullZone, d *schema.ResourceData) error {
+	if err := safeHopToResource(&pz.PullZone, d); err != nil {
+		return err
+	}
+
+	if err := headersToResource(&pz.PullZone, d); err != nil {
+		return err
+	}
+
+	if err := limitsToResource(&pz.PullZone, d); err != nil {
+		return err
+	}
+
+	optimizer, err := optimizerFlatten(pz)
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyOptimizer, err)
+	}
+
+	return d.Set(keyOptimizer, optimizer)
+}
+
+// isCopiedSetting returns true if the Pull Zone is being created and the
+// block with the given key was copied from the template Pull Zone.
+func isCopiedSetting(d *schema.ResourceData, key string) bool {
+	if !d.IsNewResource() {
+		return false
+	}
+
+	for _, copied := range d.Get(keyCopiedSettings).([]interface{}) {
+		if copied == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pullZoneCopyEdgeRules adds the edge rules of the template Pull Zone to the
+// Pull Zone with the given id.
+func pullZoneCopyEdgeRules(ctx context.Context, d *schema.ResourceData, meta interface{}, id int64) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+	templateID := int64(d.Get(keyCopySettingsFrom).(int))
+
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, templateID)
+	if err != nil {
+		return diagsErrFromErr(fmt.Sprintf("retrieving edge rules of pull zone %d failed", templateID), err)
+	}
+
+	for _, er := range edgeRules {
+		// A marker belongs to the Edge Rule of the template Pull Zone, a
+		// copy of it would be mistaken for a newly created Edge Rule.
+		description := er.Description
+		if strings.HasPrefix(ptr.GetString(description), edgeRuleMarkerPrefix) {
+			description = nil
+		}
+
+		err := clt.edgeRuleAddOrUpdate(ctx, id, &addOrUpdateEdgeRuleOptions{
+			AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+				ActionType:          er.ActionType,
+				ActionParameter1:    er.ActionParameter1,
+				ActionParameter2:    er.ActionParameter2,
+				Triggers:            er.Triggers,
+				TriggerMatchingType: er.TriggerMatchingType,
+				Description:         description,
+				Enabled:             er.Enabled,
+			},
+			ExtraActions: er.ExtraActions,
+		})
+		if err != nil {
+			return diagsErrFromErr(fmt.Sprintf("copying edge rule %q of pull zone %d failed", ptr.GetString(er.GUID), templateID), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPullZoneCopyEdgeRulesDropsMarkers(t *testing.T) {
+	fake := &fakeEdgeRuleAPI{edgeRules: []*edgeRule{
+		testEdgeRule("a", "my rule"),
+		testEdgeRule("b", newEdgeRuleMarker()),
+	}}
+	pm := fake.providerMeta(t, 0)
+
+	d := schema.TestResourceDataRaw(t, resourcePullZone().Schema, map[string]interface{}{
+		keyCopySettingsFrom: 1,
+	})
+
+	if diags := pullZoneCopyEdgeRules(context.Background(), d, pm, 1); diags.HasError() {
+		t.Fatalf("copying edge rules failed: %+v", diags)
+	}
+
+	if len(fake.edgeRules) != 4 {
+		t.Fatalf("expected 2 copied edge rules, got %d", len(fake.edgeRules)-2)
+	}
+
+	if got := ptr.GetString(fake.edgeRules[2].Description); got != "my rule" {
+		t.Errorf("expected the description of the copied edge rule to be kept, got %q", got)
+	}
+
+	if got := ptr.GetString(fake.edgeRules[3].Description); got != "" {
+		t.Errorf("expected the marker of the copied edge rule to be dropped, got %q", got)
+	}
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const keyExtraSettingsJSON = "extra_settings_json"
+
+// pullZoneUnmodeledFields contains the JSON names of the fields in
+// pullZoneUpdateOptions that are not set by any attribute of the resource.
+// They can be set via extra_settings_json.
+var pullZoneUnmodeledFields = map[string]struct{}{
+	"CookieVaryParameters":               {},
+	"EnableCookieVary":                   {},
+	"EnableQueryStringOrdering":          {},
+	"OriginShieldEnableConcurrencyLimit": {},
+	"OriginShieldMaxConcurrentRequests":  {},
+	"OriginShieldMaxQueuedRequests":      {},
+	"OriginShieldQueueMaxWaitTime":       {},
+	"QueryStringVaryParameters":          {},
+	"UseStaleWhileOffline":               {},
+	"UseStaleWhileUpdating":              {},
+	"WAFEnabled":                         {},
+	"WAFEnabledRules":                    {},
+}
+
+// pullZoneModeledFields contains the lower-cased JSON names of the Pull Zone
+// fields that are managed by attributes of the resource.
+var pullZoneModeledFields = func() map[string]struct{} {
+	res := map[string]struct{}{
+		// managed via separate API endpoints
+		"blockedreferrers": {},
+		"enabled":          {},
+	}
+
+	for _, name := range jsonFieldNames(reflect.TypeOf(pullZoneUpdateOptions{})) {
+		if _, exists := pullZoneUnmodeledFields[name]; exists {
+			continue
+		}
+
+		res[strings.ToLower(name)] = struct{}{}
+	}
+
+	return res
+}()
+
+// jsonFieldNames returns the JSON names of the exported fields of the struct
+// type t, including the fields of embedded structs.
+func jsonFieldNames(t reflect.Type) []string {
+	var res []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			res = append(res, jsonFieldNames(field.Type)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		res = append(res, name)
+	}
+
+	return res
+}
+
+var resourcePullZoneExtraSettingsJSONSchema = &schema.Schema{
+	Type: schema.TypeString,
+	Description: "A JSON object with settings that are not supported by the attributes of the resource. " +
+		"It is merged into the request body of the Update Pull Zone API endpoint. " +
+		"Only the keys that are specified are read back from the API. " +
+		"Settings that are managed by attributes of the resource can not be specified.",
+	Optional:         true,
+	ValidateDiagFunc: validateIsJSONObject,
+	DiffSuppressFunc: diffSuppressEquivalentJSON,
+}
+
+func validateIsJSONObject(i interface{}, path cty.Path) diag.Diagnostics {
+	s, ok := i.(string)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("expected type string, got: %T", i),
+			AttributePath: path,
+		}}
+	}
+
+	if _, err := parseJSONObject(s); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid JSON object",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+func diffSuppressEquivalentJSON(_, old, new string, _ *schema.ResourceData) bool {
+	oldObj, err := parseJSONObject(old)
+	if err != nil {
+		return false
+	}
+
+	newObj, err := parseJSONObject(new)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldObj, newObj)
+}
+
+// parseJSONObject unmarshals s into a map. An empty string is parsed as
+// empty object.
+func parseJSONObject(s string) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+
+	if strings.TrimSpace(s) == "" {
+		return res, nil
+	}
+
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New("expected a JSON object, got null")
+	}
+
+	return res, nil
+}
+
+// extraSettingsValidate ensures that extra_settings_json does not contain
+// settings that are managed by other attributes of the resource.
+func extraSettingsValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(keyExtraSettingsJSON) {
+		return nil
+	}
+
+	settings, err := parseJSONObject(d.Get(keyExtraSettingsJSON).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyExtraSettingsJSON, err)
+	}
+
+	var conflicts []string
+	for k := range settings {
+		if _, exists := pullZoneModeledFields[strings.ToLower(k)]; exists {
+			conflicts = append(conflicts, k)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("%s: the settings %s are managed by attributes of the resource and can not be specified",
+			keyExtraSettingsJSON, strings.Join(conflicts, ", "),
+		)
+	}
+
+	return nil
+}
+
+// extraSettingsFromResource sets the extra settings of res to the value of
+// extra_settings_json.
+func extraSettingsFromResource(res *pullZoneUpdateOptions, d *schema.ResourceData) error {
+	settings, err := parseJSONObject(d.Get(keyExtraSettingsJSON).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyExtraSettingsJSON, err)
+	}
+
+	res.extraSettings = settings
+
+	return nil
+}
+
+// extraSettingsToResource sets extra_settings_json to the values of the
+// keys in the current value of the attribute that are returned by the API.
+// Keys that are missing in the API response are omitted.
+func extraSettingsToResource(pz *pullZone, d *schema.ResourceData) error {
+	if pz.raw == nil {
+		return nil
+	}
+
+	settings, err := parseJSONObject(d.Get(keyExtraSettingsJSON).(string))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyExtraSettingsJSON, err)
+	}
+
+	if len(settings) == 0 {
+		return nil
+	}
+
+	buf, err := json.Marshal(selectJSONKeys(settings, pz.raw))
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyExtraSettingsJSON, err)
+	}
+
+	return d.Set(keyExtraSettingsJSON, string(buf))
+}
+
+// selectJSONKeys returns the values in from for the keys that exist in
+// keys. Nested objects are selected recursively. Keys are matched
+// case-insensitively, like in extraSettingsValidate, the result contains the
+// keys as they are written in keys.
+func selectJSONKeys(keys, from map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(keys))
+
+	for k, v := range keys {
+		fromV, exists := jsonObjectGet(from, k)
+		if !exists {
+			continue
+		}
+
+		keysObj, keysIsObj := v.(map[string]interface{})
+		fromObj, fromIsObj := fromV.(map[string]interface{})
+		if keysIsObj && fromIsObj {
+			res[k] = selectJSONKeys(keysObj, fromObj)
+			continue
+		}
+
+		res[k] = fromV
+	}
+
+	return res
+}
+
+// jsonObjectGet returns the value of key in obj. If obj has no exactly
+// matching key, the first key in alphabetical order that matches
+// case-insensitively is used.
+func jsonObjectGet(obj map[string]interface{}, key string) (interface{}, bool) {
+	if v, exists := obj[key]; exists {
+		return v, true
+	}
+
+	var match string
+	var found bool
+	for k := range obj {
+		if strings.EqualFold(k, key) && (!found || k < match) {
+			match = k
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	return obj[match], true
+}
+
+// mergeJSONObjects merges src into dst. Nested objects are merged
+// recursively, other values in dst are overwritten by the values in src.
+func mergeJSONObjects(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcObj, srcIsObj := v.(map[string]interface{})
+		dstObj, dstIsObj := dst[k].(map[string]interface{})
+		if srcIsObj && dstIsObj {
+			mergeJSONObjects(dstObj, srcObj)
+			continue
+		}
+
+		dst[k] = v
+	}
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSelectJSONKeys(t *testing.T) {
+	keys := map[string]interface{}{
+		"originShieldEnableConcurrencyLimit": true,
+		"Nested":                             map[string]interface{}{"a": 1.0},
+		"Missing":                            "x",
+	}
+
+	from := map[string]interface{}{
+		"OriginShieldEnableConcurrencyLimit": false,
+		"nested":                             map[string]interface{}{"A": 2.0, "B": 3.0},
+		"Other":                              "y",
+	}
+
+	want := map[string]interface{}{
+		"originShieldEnableConcurrencyLimit": false,
+		"Nested":                             map[string]interface{}{"a": 2.0},
+	}
+
+	if diff := cmp.Diff(want, selectJSONKeys(keys, from)); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+}
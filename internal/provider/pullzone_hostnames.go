@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	keyHostnames      = "hostnames"
+	keySystemHostname = "system_hostname"
+	keyCDNURL         = "cdn_url"
+
+	keyHostnamesID = "id"
+)
+
+var resourcePullZoneHostname = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyHostnamesID: {
+			Type:        schema.TypeInt,
+			Description: "The ID of the hostname.",
+			Computed:    true,
+		},
+		keyHostnameHostname: {
+			Type:        schema.TypeString,
+			Description: "The hostname value for the domain name.",
+			Computed:    true,
+		},
+		keyHostnameIsSystemHostname: {
+			Type:        schema.TypeBool,
+			Description: "Determines if this is a system hostname controlled by bunny.net.",
+			Computed:    true,
+		},
+		keyHostnameHasCertificate: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the hostname has an SSL certificate configured.",
+			Computed:    true,
+		},
+		keyHostnameForceSSL: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the Force SSL feature is enabled.",
+			Computed:    true,
+		},
+	},
+}
+
+// hostnamesToResource sets the hostnames, system_hostname and cdn_url
+// attributes to the values of the hostnames in pz.
+func hostnamesToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+	hostnames := make([]map[string]interface{}, 0, len(pz.Hostnames))
+	var systemHostname string
+
+	for _, h := range pz.Hostnames {
+		if h == nil {
+			continue
+		}
+
+		if ptr.GetBool(h.IsSystemHostname) && systemHostname == "" {
+			systemHostname = ptr.GetString(h.Value)
+		}
+
+		hostnames = append(hostnames, map[string]interface{}{
+			keyHostnamesID:              ptr.GetInt64(h.ID),
+			keyHostnameHostname:         ptr.GetString(h.Value),
+			keyHostnameIsSystemHostname: ptr.GetBool(h.IsSystemHostname),
+			keyHostnameHasCertificate:   ptr.GetBool(h.HasCertificate),
+			keyHostnameForceSSL:         ptr.GetBool(h.ForceSSL),
+		})
+	}
+
+	if err := d.Set(keyHostnames, hostnames); err != nil {
+		return err
+	}
+
+	if err := d.Set(keySystemHostname, systemHostname); err != nil {
+		return err
+	}
+
+	return d.Set(keyCDNURL, cdnURL(systemHostname))
+}
+
+// cdnURL returns the https URL of the hostname, an empty string if hostname
+// is empty.
+func cdnURL(hostname string) string {
+	if hostname == "" {
+		return ""
+	}
+
+	return "https://" + strings.ToLower(hostname)
+}
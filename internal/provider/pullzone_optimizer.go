@@ -1,8 +1,16 @@
 package provider
 
 import (
-	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -25,8 +33,94 @@ const (
 	keyOptimizerWatermarkOffset       = "offset"
 	keyOptimizerWatermarkPosition     = "position"
 	keyOptimizerWatermarkURL          = "url"
+
+	keyOptimizerForceClasses      = "force_classes"
+	keyOptimizerImageClassBlock   = "image_class"
+	keyOptimizerImageClassName    = "name"
+	keyOptimizerImageClassWidth   = "width"
+	keyOptimizerImageClassHeight  = "height"
+	keyOptimizerImageClassQuality = "quality"
+	keyOptimizerImageClassCrop    = "crop"
+
+	keyOptimizerImageClassProperties = "properties"
 )
 
+// optimizerImageClassProperties are the keys of the supported image class
+// parameters in the Properties field of an optimizer class.
+var optimizerImageClassProperties = []string{
+	keyOptimizerImageClassWidth,
+	keyOptimizerImageClassHeight,
+	keyOptimizerImageClassQuality,
+	keyOptimizerImageClassCrop,
+}
+
+var resourcePullZoneOptimizerImageClass = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyOptimizerImageClassName: {
+			Type:        schema.TypeString,
+			Description: "The name of the image class. It is used as value of the class query parameter.",
+			Required:    true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringMatch(
+					regexp.MustCompile(`^[a-zA-Z0-9_-]+$`),
+					"must only contain alphanumeric characters, underscores and dashes",
+				),
+			),
+		},
+		keyOptimizerImageClassWidth: {
+			Type:             schema.TypeInt,
+			Description:      "The width in pixels the images are resized to.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, math.MaxInt32)),
+		},
+		keyOptimizerImageClassHeight: {
+			Type:             schema.TypeInt,
+			Description:      "The height in pixels the images are resized to.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, math.MaxInt32)),
+		},
+		keyOptimizerImageClassQuality: {
+			Type:             schema.TypeInt,
+			Description:      "The compression quality of the images, between 1 and 100.",
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 100)),
+		},
+		keyOptimizerImageClassCrop: {
+			Type:        schema.TypeString,
+			Description: "Crops the images. The format is `width,height` or `width,height,x,y`.",
+			Optional:    true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringMatch(
+					regexp.MustCompile(`^\d+,\d+(,\d+,\d+)?$`),
+					"must have the format width,height or width,height,x,y",
+				),
+			),
+		},
+		keyOptimizerImageClassProperties: {
+			Type: schema.TypeMap,
+			Description: "Additional image manipulation parameters of the class by name, e.g. `blur` or `sharpen`. " +
+				"Parameters that are configured via an attribute of the block can not be set.",
+			Optional:         true,
+			Elem:             &schema.Schema{Type: schema.TypeString},
+			ValidateDiagFunc: validation.ToDiagFunc(validateOptimizerImageClassProperties),
+		},
+	},
+}
+
+func validateOptimizerImageClassProperties(i interface{}, k string) ([]string, []error) {
+	var errs []error
+
+	for name := range i.(map[string]interface{}) {
+		for _, prop := range optimizerImageClassProperties {
+			if strings.EqualFold(name, prop) {
+				errs = append(errs, fmt.Errorf("%s: %q must be set via the %s attribute", k, name, prop))
+			}
+		}
+	}
+
+	return nil, errs
+}
+
 var resourcePullZoneOptimizer = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		keyOptimizerEnabled: {
@@ -107,6 +201,19 @@ var resourcePullZoneOptimizer = &schema.Resource{
 			},
 		},
 
+		keyOptimizerForceClasses: {
+			Type:        schema.TypeBool,
+			Description: "If enabled, only requests for images that specify one of the image classes are processed by the optimizer.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyOptimizerImageClassBlock: {
+			Type:        schema.TypeList,
+			Description: "Image classes are named presets of image manipulation parameters.",
+			Optional:    true,
+			Elem:        resourcePullZoneOptimizerImageClass,
+		},
+
 		keyOptimizerWatermarkBlock: {
 			Type:             schema.TypeList,
 			MaxItems:         1,
@@ -150,7 +257,12 @@ var resourcePullZoneOptimizer = &schema.Resource{
 	},
 }
 
-func optimizerFlatten(pz *bunny.PullZone, d *schema.ResourceData) []map[string]interface{} {
+func optimizerFlatten(pz *pullZone) ([]map[string]interface{}, error) {
+	imageClasses, err := optimizerImageClassesFlatten(pz)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyOptimizerImageClassBlock, err)
+	}
+
 	return []map[string]interface{}{{
 		keyOptimizerEnabled:                     pz.OptimizerEnabled,
 		keyOptimizerEnableManipulationEngine:    pz.OptimizerEnableManipulationEngine,
@@ -159,10 +271,12 @@ func optimizerFlatten(pz *bunny.PullZone, d *schema.ResourceData) []map[string]i
 		keyOptimizerMinifyJavaScript:            pz.OptimizerMinifyJavaScript,
 		keyOptimizerSmartImageOptimizationBlock: optimizerSmartImageOptimizationFlatten(pz),
 		keyOptimizerWatermarkBlock:              optimizerWatermarkFlatten(pz),
-	}}
+		keyOptimizerForceClasses:                pz.OptimizerForceClasses,
+		keyOptimizerImageClassBlock:             imageClasses,
+	}}, nil
 }
 
-func optimizerSmartImageOptimizationFlatten(pz *bunny.PullZone) []map[string]interface{} {
+func optimizerSmartImageOptimizationFlatten(pz *pullZone) []map[string]interface{} {
 	return []map[string]interface{}{{
 		keyOptimizerAutomaticOptimizationEnabled: pz.OptimizerAutomaticOptimizationEnabled,
 		keyOptimizerDesktopMaxWidth:              pz.OptimizerDesktopMaxWidth,
@@ -172,7 +286,7 @@ func optimizerSmartImageOptimizationFlatten(pz *bunny.PullZone) []map[string]int
 	}}
 }
 
-func optimizerSmartImageOptimizationExpand(res *bunny.PullZoneUpdateOptions, m structure) {
+func optimizerSmartImageOptimizationExpand(res *pullZoneUpdateOptions, m structure) {
 	if len(m) == 0 {
 		return
 	}
@@ -184,7 +298,7 @@ func optimizerSmartImageOptimizationExpand(res *bunny.PullZoneUpdateOptions, m s
 	res.OptimizerMobileMaxWidth = m.getInt32Ptr(keyOptimizerMobileMaxWidth)
 }
 
-func optimizerWatermarkFlatten(pz *bunny.PullZone) []map[string]interface{} {
+func optimizerWatermarkFlatten(pz *pullZone) []map[string]interface{} {
 	return []map[string]interface{}{{
 		keyOptimizerWatermarkEnabled:      pz.OptimizerWatermarkEnabled,
 		keyOptimizerWatermarkURL:          pz.OptimizerWatermarkURL,
@@ -194,7 +308,7 @@ func optimizerWatermarkFlatten(pz *bunny.PullZone) []map[string]interface{} {
 	}}
 }
 
-func optimizerWatermarkExpand(res *bunny.PullZoneUpdateOptions, m structure) {
+func optimizerWatermarkExpand(res *pullZoneUpdateOptions, m structure) {
 	if len(m) == 0 {
 		return
 	}
@@ -206,7 +320,162 @@ func optimizerWatermarkExpand(res *bunny.PullZoneUpdateOptions, m structure) {
 	res.OptimizerWatermarkPosition = m.getIntPtr(keyOptimizerWatermarkPosition)
 }
 
-func optimizerFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceData) {
+func optimizerImageClassesFlatten(pz *pullZone) ([]map[string]interface{}, error) {
+	res := make([]map[string]interface{}, 0, len(pz.OptimizerClasses))
+
+	for _, class := range pz.OptimizerClasses {
+		m := map[string]interface{}{
+			keyOptimizerImageClassName: class.Name,
+		}
+
+		for _, key := range []string{
+			keyOptimizerImageClassWidth,
+			keyOptimizerImageClassHeight,
+			keyOptimizerImageClassQuality,
+		} {
+			v, exists := class.Properties[key]
+			if !exists {
+				continue
+			}
+
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("class %q: could not convert value %q of property %q to int: %w", class.Name, v, key, err)
+			}
+
+			m[key] = i
+		}
+
+		m[keyOptimizerImageClassCrop] = class.Properties[keyOptimizerImageClassCrop]
+		m[keyOptimizerImageClassProperties] = optimizerImageClassOtherProperties(class)
+
+		res = append(res, m)
+	}
+
+	return res, nil
+}
+
+// optimizerImageClassOtherProperties returns the properties of the class
+// that have no own attribute.
+func optimizerImageClassOtherProperties(class *pullZoneOptimizerClass) map[string]interface{} {
+	res := map[string]interface{}{}
+
+	for k, v := range class.Properties {
+		var supported bool
+		for _, prop := range optimizerImageClassProperties {
+			if k == prop {
+				supported = true
+				break
+			}
+		}
+
+		if !supported {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+func optimizerImageClassesExpand(res *pullZoneUpdateOptions, classes []interface{}) {
+	result := make([]*pullZoneOptimizerClass, 0, len(classes))
+
+	for _, elem := range classes {
+		m := structure(elem.(map[string]interface{}))
+		class := pullZoneOptimizerClass{
+			Name:       m.getStr(keyOptimizerImageClassName),
+			Properties: map[string]string{},
+		}
+
+		for _, key := range []string{
+			keyOptimizerImageClassWidth,
+			keyOptimizerImageClassHeight,
+			keyOptimizerImageClassQuality,
+		} {
+			if v := m[key].(int); v != 0 {
+				class.Properties[key] = strconv.Itoa(v)
+			}
+		}
+
+		if v := m.getStr(keyOptimizerImageClassCrop); v != "" {
+			class.Properties[keyOptimizerImageClassCrop] = v
+		}
+
+		props, _ := m[keyOptimizerImageClassProperties].(map[string]interface{})
+		for k, v := range props {
+			class.Properties[k] = v.(string)
+		}
+
+		result = append(result, &class)
+	}
+
+	res.OptimizerClasses = &result
+}
+
+// optimizerValidate ensures that the names of the image classes are unique
+// and that every image class has at least one parameter.
+func optimizerValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	m := structureFromResource(d, keyOptimizer)
+	if m.isEmpty() {
+		return nil
+	}
+
+	classes := m[keyOptimizerImageClassBlock].([]interface{})
+	names := make(map[string]struct{}, len(classes))
+	var errs []string
+
+	classParamKeys := make([]string, 0, len(optimizerImageClassProperties)+1)
+	classParamKeys = append(classParamKeys, optimizerImageClassProperties...)
+	classParamKeys = append(classParamKeys, keyOptimizerImageClassProperties)
+
+	for i, elem := range classes {
+		blockKey := fmt.Sprintf("%s.0.%s.%d", keyOptimizer, keyOptimizerImageClassBlock, i)
+		class := structure(elem.(map[string]interface{}))
+
+		if d.NewValueKnown(blockKey + "." + keyOptimizerImageClassName) {
+			name := class.getStr(keyOptimizerImageClassName)
+			if _, exists := names[name]; exists {
+				errs = append(errs, fmt.Sprintf("%s.%s: image class name %q is not unique", blockKey, keyOptimizerImageClassName, name))
+			}
+			names[name] = struct{}{}
+		}
+
+		var hasProperty bool
+		for _, key := range classParamKeys {
+			if !d.NewValueKnown(blockKey + "." + key) {
+				hasProperty = true
+				break
+			}
+
+			switch v := class[key].(type) {
+			case int:
+				hasProperty = v != 0
+			case string:
+				hasProperty = v != ""
+			case map[string]interface{}:
+				hasProperty = len(v) > 0
+			}
+
+			if hasProperty {
+				break
+			}
+		}
+
+		if !hasProperty {
+			errs = append(errs, fmt.Sprintf("%s: at least one of %s must be set",
+				blockKey, strings.Join(classParamKeys, ", ")),
+			)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func optimizerFromResource(res *pullZoneUpdateOptions, d *schema.ResourceData) {
 	m := structureFromResource(d, keyOptimizer)
 	if len(m) == 0 {
 		return
@@ -223,4 +492,7 @@ func optimizerFromResource(res *bunny.PullZoneUpdateOptions, d *schema.ResourceD
 
 	watermarkBlock := m[keyOptimizerWatermarkBlock].([]interface{})
 	optimizerWatermarkExpand(res, structureFromElem(watermarkBlock))
+
+	res.OptimizerForceClasses = m.getBoolPtr(keyOptimizerForceClasses)
+	optimizerImageClassesExpand(res, m[keyOptimizerImageClassBlock].([]interface{}))
 }
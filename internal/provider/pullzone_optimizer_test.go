@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOptimizerImageClassesRoundTrip(t *testing.T) {
+	classes := []*pullZoneOptimizerClass{
+		{
+			Name: "thumb",
+			Properties: map[string]string{
+				keyOptimizerImageClassWidth: "200",
+				keyOptimizerImageClassCrop:  "200,100",
+				"blur":                      "10",
+				"sharpen":                   "true",
+			},
+		},
+	}
+
+	optimizer, err := optimizerFlatten(&pullZone{OptimizerClasses: classes})
+	if err != nil {
+		t.Fatalf("optimizerFlatten failed: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePullZone().Schema, map[string]interface{}{})
+	if err := d.Set(keyOptimizer, optimizer); err != nil {
+		t.Fatalf("setting %s failed: %s", keyOptimizer, err)
+	}
+
+	wantProps := map[string]interface{}{"blur": "10", "sharpen": "true"}
+	if diff := cmp.Diff(wantProps, d.Get(keyOptimizer+".0."+keyOptimizerImageClassBlock+".0."+keyOptimizerImageClassProperties)); diff != "" {
+		t.Errorf("unexpected %s (-want +got):\n%s", keyOptimizerImageClassProperties, diff)
+	}
+
+	var res pullZoneUpdateOptions
+	optimizerFromResource(&res, d)
+
+	if diff := cmp.Diff(classes, *res.OptimizerClasses); diff != "" {
+		t.Errorf("image classes differ after flattening and expanding (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyOriginBlockType          = "type"
+	keyOriginBlockURL           = "url"
+	keyOriginBlockStorageZoneID = "storage_zone_id"
+	keyOriginBlockEdgeScriptID  = "edge_script_id"
+	keyOriginBlockHostHeader    = "host_header"
+)
+
+// Values of the OriginType field of a Pull Zone.
+const (
+	pullZoneOriginTypeURL            = 0
+	pullZoneOriginTypeDNSAccelerated = 1
+	pullZoneOriginTypeStorageZone    = 2
+	pullZoneOriginTypeEdgeScript     = 4
+)
+
+var pullZoneOriginTypesStr = map[string]int{
+	"url":             pullZoneOriginTypeURL,
+	"dns_accelerated": pullZoneOriginTypeDNSAccelerated,
+	"storage_zone":    pullZoneOriginTypeStorageZone,
+	"edge_script":     pullZoneOriginTypeEdgeScript,
+}
+
+var pullZoneOriginTypesInt = reverseStrIntMap(pullZoneOriginTypesStr)
+
+var pullZoneOriginTypeKeys = strIntMapKeysSorted(pullZoneOriginTypesStr)
+
+var resourcePullZoneOrigin = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyOriginBlockType: {
+			Type: schema.TypeString,
+			Description: "The type of the origin.\nValid values: " +
+				strings.Join(pullZoneOriginTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(pullZoneOriginTypeKeys, false),
+			),
+		},
+		keyOriginBlockURL: {
+			Type:        schema.TypeString,
+			Description: "The URL from that the files are fetched. Required for the origin types `url` and `dns_accelerated`.",
+			Optional:    true,
+		},
+		keyOriginBlockStorageZoneID: {
+			Type:        schema.TypeInt,
+			Description: "The ID of the storage zone from that the files are served. Required for the origin type `storage_zone`.",
+			Optional:    true,
+		},
+		keyOriginBlockEdgeScriptID: {
+			Type:        schema.TypeInt,
+			Description: "The ID of the edge script that handles the requests. Required for the origin type `edge_script`.",
+			Optional:    true,
+		},
+		keyOriginBlockHostHeader: {
+			Type:        schema.TypeString,
+			Description: "Overrides the Host header that is sent to the origin. Useful for virtual-hosted S3-compatible backends.",
+			Optional:    true,
+		},
+		keyAWSSigningEnabled: {
+			Type:        schema.TypeBool,
+			Description: fmt.Sprintf("Determines if requests to the origin are signed with AWS Signature Version 4. Requires %s, %s and %s.", keyAWSSigningKey, keyAWSSigningSecret, keyAWSSigningRegionName),
+			Optional:    true,
+			Default:     false,
+		},
+		keyAWSSigningKey: {
+			Type:        schema.TypeString,
+			Description: "The AWS access key ID used for signing.",
+			Optional:    true,
+		},
+		keyAWSSigningSecret: {
+			Type:        schema.TypeString,
+			Description: "The AWS secret access key used for signing.",
+			Optional:    true,
+			Sensitive:   true,
+		},
+		keyAWSSigningRegionName: {
+			Type:        schema.TypeString,
+			Description: "The AWS region name used for signing.",
+			Optional:    true,
+		},
+	},
+}
+
+func originToResource(pz *pullZone, d *schema.ResourceData) error {
+	if pz.OriginType == nil {
+		return d.Set(keyOrigin, nil)
+	}
+
+	originType := int(*pz.OriginType)
+	originTypeStr, err := intStrMapGet(pullZoneOriginTypesInt, &originType)
+	if err != nil {
+		logger.Warnf("originToResource: pull zone has unsupported origin type %d, not setting %s block", originType, keyOrigin)
+		return d.Set(keyOrigin, nil)
+	}
+
+	m := map[string]interface{}{}
+
+	m[keyOriginBlockType] = originTypeStr
+	switch originType {
+	case pullZoneOriginTypeURL, pullZoneOriginTypeDNSAccelerated:
+		m[keyOriginBlockURL] = pz.OriginURL
+	case pullZoneOriginTypeStorageZone:
+		m[keyOriginBlockStorageZoneID] = pz.StorageZoneID
+	case pullZoneOriginTypeEdgeScript:
+		m[keyOriginBlockEdgeScriptID] = pz.EdgeScriptID
+	}
+	m[keyOriginBlockHostHeader] = pz.OriginHostHeader
+	m[keyAWSSigningEnabled] = pz.AWSSigningEnabled
+	m[keyAWSSigningKey] = pz.AWSSigningKey
+	m[keyAWSSigningSecret] = pz.AWSSigningSecret
+	m[keyAWSSigningRegionName] = pz.AWSSigningRegionName
+
+	return d.Set(keyOrigin, []map[string]interface{}{m})
+}
+
+func originFromResource(res *pullZoneUpdateOptions, d *schema.ResourceData) error {
+	m := structureFromResource(d, keyOrigin)
+	if len(m) == 0 {
+		return nil
+	}
+
+	originType, err := strIntMapGet(pullZoneOriginTypesStr, m.getStr(keyOriginBlockType))
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", keyOrigin, keyOriginBlockType, err)
+	}
+
+	res.OriginType = ptr.ToInt32(int32(originType))
+	switch originType {
+	case pullZoneOriginTypeURL, pullZoneOriginTypeDNSAccelerated:
+		res.OriginURL = m.getStrPtr(keyOriginBlockURL)
+	case pullZoneOriginTypeStorageZone:
+		res.StorageZoneID = m.getInt64Ptr(keyOriginBlockStorageZoneID)
+	case pullZoneOriginTypeEdgeScript:
+		res.EdgeScriptID = m.getInt64Ptr(keyOriginBlockEdgeScriptID)
+	}
+
+	res.OriginHostHeader = m.getStrPtr(keyOriginBlockHostHeader)
+	res.AWSSigningEnabled = m.getBoolPtr(keyAWSSigningEnabled)
+	res.AWSSigningKey = m.getStrPtr(keyAWSSigningKey)
+	res.AWSSigningSecret = m.getStrPtr(keyAWSSigningSecret)
+	res.AWSSigningRegionName = m.getStrPtr(keyAWSSigningRegionName)
+
+	return nil
+}
+
+// originShorthandDiff updates the plan of the computed counterpart when the
+// origin is switched between origin_url and storage_zone_id.
+// storage_zone_id is planned as 0 when origin_url is configured instead of
+// it, origin_url becomes unknown when storage_zone_id is configured instead
+// of it.
+func originShorthandDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || isConfiguredInDiff(d, keyOrigin) {
+		return nil
+	}
+
+	if isConfiguredInDiff(d, keyOriginURL) {
+		if d.Get(keyStorageZoneID).(int) != 0 {
+			return d.SetNew(keyStorageZoneID, 0)
+		}
+
+		return nil
+	}
+
+	if isConfiguredInDiff(d, keyStorageZoneID) && d.HasChange(keyStorageZoneID) {
+		return d.SetNewComputed(keyOriginURL)
+	}
+
+	return nil
+}
+
+// originValidate ensures that the attributes required by the origin type
+// are set, that no attributes of other origin types are set and that the
+// aws_signing attributes are specified together.
+// Attributes with values that are unknown during planning are not
+// validated.
+func originValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	m := structureFromResource(d, keyOrigin)
+	if m.isEmpty() {
+		return nil
+	}
+
+	blockKey := func(key string) string {
+		return keyOrigin + ".0." + key
+	}
+
+	isSet := func(key string) bool {
+		if !d.NewValueKnown(blockKey(key)) {
+			return true
+		}
+
+		switch v := m[key].(type) {
+		case string:
+			return v != ""
+		case int:
+			return v != 0
+		case bool:
+			return v
+		}
+
+		return false
+	}
+
+	var errs []string
+
+	requireAttrs := func(originType string, keys ...string) {
+		for _, key := range keys {
+			if !isSet(key) {
+				errs = append(errs, fmt.Sprintf("%s is required for origin type %q", blockKey(key), originType))
+			}
+		}
+	}
+
+	rejectAttrs := func(originType string, keys ...string) {
+		for _, key := range keys {
+			if d.NewValueKnown(blockKey(key)) && isSet(key) {
+				errs = append(errs, fmt.Sprintf("%s can not be set for origin type %q", blockKey(key), originType))
+			}
+		}
+	}
+
+	originType := m.getStr(keyOriginBlockType)
+	switch originType {
+	case "url", "dns_accelerated":
+		requireAttrs(originType, keyOriginBlockURL)
+		rejectAttrs(originType, keyOriginBlockStorageZoneID, keyOriginBlockEdgeScriptID)
+	case "storage_zone":
+		requireAttrs(originType, keyOriginBlockStorageZoneID)
+		rejectAttrs(originType, keyOriginBlockURL, keyOriginBlockEdgeScriptID)
+	case "edge_script":
+		requireAttrs(originType, keyOriginBlockEdgeScriptID)
+		rejectAttrs(originType, keyOriginBlockURL, keyOriginBlockStorageZoneID)
+	}
+
+	awsKeys := []string{keyAWSSigningKey, keyAWSSigningSecret, keyAWSSigningRegionName}
+	awsConfigured := isSet(keyAWSSigningEnabled)
+	for _, key := range awsKeys {
+		awsConfigured = awsConfigured || isSet(key)
+	}
+
+	if awsConfigured {
+		for _, key := range awsKeys {
+			if !isSet(key) {
+				errs = append(errs, fmt.Sprintf("%s must be set together with %s",
+					blockKey(key), strings.Join(awsKeys, ", ")),
+				)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
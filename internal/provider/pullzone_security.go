@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keySecurityDDoSProtectionEnabled = "ddos_protection_enabled"
+	keySecurityDDoSProtectionType    = "ddos_protection_type"
+	keySecurityAutoSSLEnabled        = "auto_ssl_enabled"
+)
+
+// Values of the ShieldDDosProtectionType field of a Pull Zone.
+const (
+	pullZoneDDoSProtectionTypeDetectOnly       = 0
+	pullZoneDDoSProtectionTypeActiveStandard   = 1
+	pullZoneDDoSProtectionTypeActiveAggressive = 2
+)
+
+var pullZoneDDoSProtectionTypesStr = map[string]int{
+	"detect_only":       pullZoneDDoSProtectionTypeDetectOnly,
+	"active_standard":   pullZoneDDoSProtectionTypeActiveStandard,
+	"active_aggressive": pullZoneDDoSProtectionTypeActiveAggressive,
+}
+
+var pullZoneDDoSProtectionTypesInt = reverseStrIntMap(pullZoneDDoSProtectionTypesStr)
+
+var pullZoneDDoSProtectionTypeKeys = strIntMapKeysSorted(pullZoneDDoSProtectionTypesStr)
+
+var resourcePullZoneSecurity = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keySecurityDDoSProtectionEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the DDoS protection of bunny Shield is enabled.",
+			Optional:    true,
+			Computed:    true,
+		},
+		keySecurityDDoSProtectionType: {
+			Type: schema.TypeString,
+			Description: "The mode of the DDoS protection of bunny Shield. Modes that are not supported by the provider are read as their numeric value.\nValid values: " +
+				strings.Join(pullZoneDDoSProtectionTypeKeys, ", "),
+			Optional: true,
+			Computed: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(pullZoneDDoSProtectionTypeKeys, false),
+			),
+		},
+		keySecurityAutoSSLEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if free SSL certificates are automatically issued for hostnames that are added to the zone.",
+			Optional:    true,
+			Computed:    true,
+		},
+		keyBlockPostRequests: {
+			Type:        schema.TypeBool,
+			Description: "Determines if POST requests to the zone are rejected.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyBlockRootPathAccess: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the zone should block requests to the root of the zone.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyZoneSecurityEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if token authentication is enabled for the zone.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyZoneSecurityIncludeHashRemoteIP: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the token authentication hash must contain the IP of the client.",
+			Optional:    true,
+			Default:     false,
+		},
+		keyEnableTLS1: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the TLS 1 should be enabled on this zone.",
+			Optional:    true,
+			Default:     true,
+		},
+		keyEnableTLS11: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the TLS 1.1 should be enabled on this zone.",
+			Optional:    true,
+			Default:     true,
+		},
+	},
+}
+
+func securityToResource(pz *pullZone, d *schema.ResourceData) error {
+	m := map[string]interface{}{}
+
+	if pz.ShieldDDosProtectionType != nil {
+		ddosType, err := intStrMapGet(pullZoneDDoSProtectionTypesInt, pz.ShieldDDosProtectionType)
+		if err != nil {
+			// keep the raw value, it is sent back unchanged on updates
+			logger.Warnf("securityToResource: pull zone has unsupported DDoS protection type %d, setting %s.%s to the raw value",
+				*pz.ShieldDDosProtectionType, keySecurity, keySecurityDDoSProtectionType)
+			ddosType = strconv.Itoa(*pz.ShieldDDosProtectionType)
+		}
+
+		m[keySecurityDDoSProtectionType] = ddosType
+	}
+
+	m[keySecurityDDoSProtectionEnabled] = pz.ShieldDDosProtectionEnabled
+	m[keySecurityAutoSSLEnabled] = pz.EnableAutoSSL
+	m[keyBlockPostRequests] = pz.BlockPostRequests
+	m[keyBlockRootPathAccess] = pz.BlockRootPathAccess
+	m[keyZoneSecurityEnabled] = pz.ZoneSecurityEnabled
+	m[keyZoneSecurityIncludeHashRemoteIP] = pz.ZoneSecurityIncludeHashRemoteIP
+	m[keyEnableTLS1] = pz.EnableTLS1
+	m[keyEnableTLS11] = pz.EnableTLS11
+
+	return d.Set(keySecurity, []map[string]interface{}{m})
+}
+
+func securityFromResource(res *pullZoneUpdateOptions, d *schema.ResourceData) error {
+	m := structureFromResource(d, keySecurity)
+	if len(m) == 0 {
+		return nil
+	}
+
+	if ddosType := m.getStr(keySecurityDDoSProtectionType); ddosType != "" {
+		v, err := strIntMapGet(pullZoneDDoSProtectionTypesStr, ddosType)
+		if err != nil {
+			// a raw value of an unsupported type that was read from the API
+			var convErr error
+			if v, convErr = strconv.Atoi(ddosType); convErr != nil {
+				return fmt.Errorf("%s.%s: %w", keySecurity, keySecurityDDoSProtectionType, err)
+			}
+		}
+
+		res.ShieldDDosProtectionType = ptr.ToInt(v)
+	}
+
+	// the attributes are Optional+Computed, only send them when they are
+	// configured, otherwise their zero values would be sent
+	if isConfigured(d, keySecurity+".0."+keySecurityDDoSProtectionEnabled) {
+		res.ShieldDDosProtectionEnabled = m.getBoolPtr(keySecurityDDoSProtectionEnabled)
+	}
+	if isConfigured(d, keySecurity+".0."+keySecurityAutoSSLEnabled) {
+		res.EnableAutoSSL = m.getBoolPtr(keySecurityAutoSSLEnabled)
+	}
+	res.BlockPostRequests = m.getBoolPtr(keyBlockPostRequests)
+	res.BlockRootPathAccess = m.getBoolPtr(keyBlockRootPathAccess)
+	res.ZoneSecurityEnabled = m.getBoolPtr(keyZoneSecurityEnabled)
+	res.ZoneSecurityIncludeHashRemoteIP = m.getBoolPtr(keyZoneSecurityIncludeHashRemoteIP)
+	res.EnableTLS1 = m.getBoolPtr(keyEnableTLS1)
+	res.EnableTLS11 = m.getBoolPtr(keyEnableTLS11)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSecurityUnsupportedDDoSProtectionTypeIsKept(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePullZone().Schema, map[string]interface{}{})
+
+	var pz pullZone
+	pz.ShieldDDosProtectionType = ptr.ToInt(7)
+
+	if err := securityToResource(&pz, d); err != nil {
+		t.Fatalf("securityToResource failed: %s", err)
+	}
+
+	v := d.Get(keySecurity + ".0." + keySecurityDDoSProtectionType)
+	if v != "7" {
+		t.Fatalf("expected %s to be the raw value \"7\", got: %q", keySecurityDDoSProtectionType, v)
+	}
+
+	var res pullZoneUpdateOptions
+	if err := securityFromResource(&res, d); err != nil {
+		t.Fatalf("securityFromResource failed: %s", err)
+	}
+
+	if ptr.GetInt(res.ShieldDDosProtectionType) != 7 {
+		t.Errorf("expected the raw DDoS protection type 7 to be sent, got: %v", res.ShieldDDosProtectionType)
+	}
+}
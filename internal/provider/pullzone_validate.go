@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pullZoneTypeVolume is the value of the type attribute of a Volume Pull
+// Zone.
+const pullZoneTypeVolume = 1
+
+// pullZoneCrossFieldValidate rejects combinations of attribute values that
+// the API refuses or silently ignores.
+// Only combinations that contain a configured attribute are checked, to not
+// fail planning because of values that were only read from the API.
+func pullZoneCrossFieldValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	var errs attrErrors
+
+	// isTrue returns true if the attribute is configured and its planned
+	// value is true
+	isTrue := func(key string) bool {
+		return isConfiguredInDiff(d, key) && d.NewValueKnown(key) && d.Get(key).(bool)
+	}
+
+	// isUnset returns true if the planned value of the attribute is known
+	// and the zero value
+	isUnset := func(key string) bool {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+
+		switch v := d.Get(key).(type) {
+		case string:
+			return v == ""
+		case int:
+			return v == 0
+		}
+
+		return false
+	}
+
+	requireWhenEnabled := func(enabledKey string, keys ...string) {
+		if !isTrue(enabledKey) {
+			return
+		}
+
+		for _, key := range keys {
+			if isUnset(key) {
+				errs.add(cty.GetAttrPath(key), "%s is required when %s is true", key, enabledKey)
+			}
+		}
+	}
+
+	requireWhenEnabled(keyAWSSigningEnabled, keyAWSSigningKey, keyAWSSigningSecret, keyAWSSigningRegionName)
+	requireWhenEnabled(keyLogForwardingEnabled, keyLogForwardingHostname, keyLogForwardingPort)
+	requireWhenEnabled(keyLoggingSaveToStorage, keyLoggingStorageZoneID)
+	requireWhenEnabled(keyErrorPageEnableCustomCode, keyErrorPageCustomCode)
+
+	optimizerEnabledKey := keyOptimizer + ".0." + keyOptimizerEnabled
+	if isConfiguredInDiff(d, keyOptimizer) &&
+		d.NewValueKnown(keyType) && d.Get(keyType).(int) == pullZoneTypeVolume &&
+		d.NewValueKnown(optimizerEnabledKey) && d.Get(optimizerEnabledKey).(bool) {
+		errs.add(
+			cty.GetAttrPath(keyOptimizer).IndexInt(0).GetAttr(keyOptimizerEnabled),
+			"the optimizer is not supported by Volume Pull Zones (%s = %d)", keyType, pullZoneTypeVolume,
+		)
+	}
+
+	return errs.err()
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPullZoneCrossFieldValidateAttributePath(t *testing.T) {
+	r := resourcePullZone()
+	cfg := testObjectVal(r, r.CoreConfigSchema().ImpliedType(), map[string]cty.Value{
+		keyName:                 cty.StringVal("test"),
+		keyOriginURL:            cty.StringVal("https://bunny.net"),
+		keyLoggingSaveToStorage: cty.True,
+	})
+
+	path := testDiagAttributePath(t, testPlanCreate(t, "bunny_pullzone", cfg))
+
+	want := tftypes.NewAttributePath().WithAttributeName(keyLoggingStorageZoneID)
+	if !path.Equal(want) {
+		t.Errorf("expected the diagnostic for attribute %s, got: %s", want, path)
+	}
+}
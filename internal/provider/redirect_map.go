@@ -0,0 +1,529 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+)
+
+// Limits that are used when compiling a redirect map into Edge Rules.
+const (
+	// redirectMapMaxTriggers is the maximum number of triggers per Edge
+	// Rule, the API returns the error "Maximum 5 condition are allowed per
+	// rule" for more.
+	redirectMapMaxTriggers = 5
+	// redirectMapMaxPatternMatches is the maximum number of pattern matches
+	// per trigger.
+	redirectMapMaxPatternMatches = 50
+)
+
+var redirectMapStatusCodes = []int{301, 302, 307, 308}
+
+// redirect is a single entry of a redirect map.
+type redirect struct {
+	source      string
+	destination string
+	statusCode  int
+	// line is the line of the entry in the CSV file, it is 0 for entries
+	// of the redirects attribute.
+	line int
+}
+
+func (r *redirect) String() string {
+	if r.line == 0 {
+		return fmt.Sprintf("redirect %q", r.source)
+	}
+
+	return fmt.Sprintf("line %d", r.line)
+}
+
+// redirectMapFromMap returns the redirects of the map, sorted by source.
+func redirectMapFromMap(m map[string]interface{}, statusCode int) []*redirect {
+	res := make([]*redirect, 0, len(m))
+
+	for source, destination := range m {
+		res = append(res, &redirect{
+			source:      source,
+			destination: destination.(string),
+			statusCode:  statusCode,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].source < res[j].source
+	})
+
+	return res
+}
+
+// redirectMapFromCSVFile parses the CSV file at path.
+func redirectMapFromCSVFile(path string, defaultStatusCode int) ([]*redirect, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return redirectMapFromCSV(f, defaultStatusCode)
+}
+
+// redirectMapFromCSV parses redirects in the format
+// source,destination[,status_code]. Lines starting with # are ignored. A
+// header line starting with the column name "source" is skipped.
+func redirectMapFromCSV(r io.Reader, defaultStatusCode int) ([]*redirect, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var res []*redirect
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if len(res) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "source") {
+			continue
+		}
+
+		if len(record) != 2 && len(record) != 3 {
+			return nil, fmt.Errorf("line %d: expected 2 or 3 columns (source,destination[,status_code]), got %d", line, len(record))
+		}
+
+		r := redirect{
+			source:      strings.TrimSpace(record[0]),
+			destination: strings.TrimSpace(record[1]),
+			statusCode:  defaultStatusCode,
+			line:        line,
+		}
+
+		if len(record) == 3 && strings.TrimSpace(record[2]) != "" {
+			r.statusCode, err = strconv.Atoi(strings.TrimSpace(record[2]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: status code %q is not a number", line, record[2])
+			}
+		}
+
+		res = append(res, &r)
+	}
+}
+
+// redirectMapPlaceholderHostname is used as hostname of the Pull Zone by
+// redirectMapValidate if the hostnames are not known yet.
+const redirectMapPlaceholderHostname = "pull-zone.invalid"
+
+// redirectMapValidate returns an error per invalid entry, duplicate source
+// and redirect loop. hostnames are the hostnames of the Pull Zone, if they
+// are not known yet, redirectMapPlaceholderHostname is used.
+// A loop is detected when following the destinations of the redirects
+// leads to a source that was already visited. A destination leads to a
+// source if one of its URLs on the Pull Zone, see
+// redirectMapDestinationURLs, is matched by a trigger pattern of the
+// source.
+func redirectMapValidate(redirects []*redirect, hostnames []string) []error {
+	var errs []error
+
+	if len(hostnames) == 0 {
+		hostnames = []string{redirectMapPlaceholderHostname}
+	}
+
+	bySource := make(map[string]*redirect, len(redirects))
+	var sources []string
+
+	for _, r := range redirects {
+		if err := validateRedirectMapSource(r.source); err != nil {
+			errs = append(errs, fmt.Errorf("%s: source %q %w", r, r.source, err))
+		}
+
+		if err := validateRedirectMapDestination(r.destination); err != nil {
+			errs = append(errs, fmt.Errorf("%s: destination %q %w", r, r.destination, err))
+		}
+
+		if !intSliceContains(redirectMapStatusCodes, r.statusCode) {
+			errs = append(errs, fmt.Errorf("%s: unsupported status code %d, supported: %s",
+				r, r.statusCode, intSliceJoin(redirectMapStatusCodes, ", ")))
+		}
+
+		if dup, exists := bySource[r.source]; exists {
+			errs = append(errs, fmt.Errorf("%s: duplicate source %q, it is already defined in %s", r, r.source, dup))
+			continue
+		}
+
+		bySource[r.source] = r
+		sources = append(sources, r.source)
+	}
+
+	patterns := make(map[string][]string, len(sources))
+	for _, source := range sources {
+		patterns[source] = redirectMapSourcePatterns(source, hostnames)
+	}
+
+	// next returns the sources that the destination of the redirect of src
+	// leads to, in the order of the redirects.
+	next := func(src string) []string {
+		var res []string
+
+		for _, u := range redirectMapDestinationURLs(bySource[src].destination, hostnames) {
+			for _, source := range sources {
+				if redirectMapPatternsMatch(patterns[source], u) && !strSliceContains(res, source) {
+					res = append(res, source)
+				}
+			}
+		}
+
+		return res
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(sources))
+
+	var path []string
+
+	var visit func(src string)
+	visit = func(src string) {
+		state[src] = visiting
+		path = append(path, src)
+
+		for _, n := range next(src) {
+			switch state[n] {
+			case unvisited:
+				visit(n)
+
+			case visiting:
+				for i, p := range path {
+					if p == n {
+						errs = append(errs, fmt.Errorf("redirect loop: %s -> %s",
+							strings.Join(path[i:], " -> "), n))
+						break
+					}
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[src] = visited
+	}
+
+	for _, source := range sources {
+		if state[source] == unvisited {
+			visit(source)
+		}
+	}
+
+	return errs
+}
+
+func validateRedirectMapSource(source string) error {
+	if !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return errors.New(`must start with "/", "http://" or "https://"`)
+	}
+
+	if strings.Contains(source, "*") {
+		return errors.New(`must not contain wildcards ("*")`)
+	}
+
+	return nil
+}
+
+func validateRedirectMapDestination(destination string) error {
+	if !strings.HasPrefix(destination, "/") && !strings.HasPrefix(destination, "http://") && !strings.HasPrefix(destination, "https://") {
+		return errors.New(`must start with "/", "http://" or "https://"`)
+	}
+
+	return nil
+}
+
+// redirectMapCompile groups the redirects by destination and status code
+// into Edge Rules. An Edge Rule has up to
+// redirectMapMaxTriggers * redirectMapMaxPatternMatches patterns, larger
+// groups are split into buckets via redirectMapBuckets. Adding or removing a
+// redirect therefore only changes the Edge Rule of its bucket.
+// The Edge Rules are returned by a key that identifies them, it consists of
+// the status code, destination and the number of the bucket.
+// The description of the Edge Rules is prefixed with descriptionPrefix.
+// hostnames are the hostnames of the Pull Zone, sources that are paths are
+// matched on each of them, see redirectMapSourcePatterns.
+func redirectMapCompile(redirects []*redirect, hostnames []string, descriptionPrefix string) map[string]*addOrUpdateEdgeRuleOptions {
+	type group struct {
+		destination string
+		statusCode  int
+		sources     []string
+	}
+
+	groups := map[string]*group{}
+
+	for _, r := range redirects {
+		k := fmt.Sprintf("%d %s", r.statusCode, r.destination)
+
+		g, exists := groups[k]
+		if !exists {
+			g = &group{destination: r.destination, statusCode: r.statusCode}
+			groups[k] = g
+		}
+
+		g.sources = append(g.sources, r.source)
+	}
+
+	// a source that is a path has a pattern per hostname
+	sourcesPerRule := redirectMapMaxTriggers * redirectMapMaxPatternMatches
+	if len(hostnames) > 1 {
+		sourcesPerRule /= len(hostnames)
+	}
+	if sourcesPerRule < 1 {
+		sourcesPerRule = 1
+	}
+
+	res := map[string]*addOrUpdateEdgeRuleOptions{}
+
+	for k, g := range groups {
+		for bucket, sources := range redirectMapBuckets(g.sources, sourcesPerRule) {
+			sort.Strings(sources)
+
+			var patterns []string
+			for _, source := range sources {
+				patterns = append(patterns, redirectMapSourcePatterns(source, hostnames)...)
+			}
+
+			var triggers []*bunny.EdgeRuleTrigger
+			for len(patterns) > 0 {
+				n := len(patterns)
+				if n > redirectMapMaxPatternMatches {
+					n = redirectMapMaxPatternMatches
+				}
+
+				triggers = append(triggers, edgeRuleURLTrigger(bunny.MatchingTypeAny, patterns[:n]...))
+				patterns = patterns[n:]
+			}
+
+			ruleKey := fmt.Sprintf("%s #%d", k, bucket)
+
+			res[ruleKey] = &addOrUpdateEdgeRuleOptions{
+				AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+					ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeRedirect),
+					ActionParameter1:    ptr.ToString(g.destination),
+					ActionParameter2:    ptr.ToString(strconv.Itoa(g.statusCode)),
+					Triggers:            triggers,
+					TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+					Description:         ptr.ToString(descriptionPrefix + ruleKey),
+					Enabled:             ptr.ToBool(true),
+				},
+				ExtraActions: []*edgeRuleAction{},
+			}
+		}
+	}
+
+	return res
+}
+
+// redirectMapMaxBucketDepth limits how often redirectMapBuckets splits a
+// bucket, it keeps the bucket numbers within an int64.
+const redirectMapMaxBucketDepth = 62
+
+// redirectMapBuckets distributes the sources to buckets of at most size
+// sources and returns them by their bucket number.
+// The buckets form a binary tree: all sources start in bucket 1, a bucket
+// with more than size sources is split into the buckets 2n and 2n+1 by the
+// next bit of the SHA-256 hash of the sources. The bucket of a source only
+// depends on the sources that share its bucket, adding or removing a source
+// only changes its own bucket, or splits or merges it.
+// Empty buckets are omitted.
+func redirectMapBuckets(sources []string, size int) map[int64][]string {
+	hashes := make(map[string][sha256.Size]byte, len(sources))
+	for _, source := range sources {
+		hashes[source] = sha256.Sum256([]byte(source))
+	}
+
+	res := map[int64][]string{}
+
+	var split func(bucket int64, depth int, sources []string)
+	split = func(bucket int64, depth int, sources []string) {
+		if len(sources) == 0 {
+			return
+		}
+
+		if len(sources) <= size || depth >= redirectMapMaxBucketDepth {
+			res[bucket] = sources
+			return
+		}
+
+		var zero, one []string
+		for _, source := range sources {
+			h := hashes[source]
+			if h[depth/8]&(0x80>>(depth%8)) == 0 {
+				zero = append(zero, source)
+			} else {
+				one = append(one, source)
+			}
+		}
+
+		split(2*bucket, depth+1, zero)
+		split(2*bucket+1, depth+1, one)
+	}
+
+	split(1, 0, sources)
+
+	return res
+}
+
+// redirectMapSourcePatterns returns the patterns of the URL trigger that
+// match the source. The URL trigger matches the full request URL, a path is
+// anchored to the scheme and each hostname of the Pull Zone, e.g. the path
+// /a is matched by http*://example.b-cdn.net/a but not by the request for
+// /b/a.
+func redirectMapSourcePatterns(source string, hostnames []string) []string {
+	if !strings.HasPrefix(source, "/") {
+		return []string{source}
+	}
+
+	res := make([]string, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		res = append(res, "http*://"+hostname+source)
+	}
+
+	return res
+}
+
+// redirectMapDestinationURLs returns the URLs on the Pull Zone that are
+// requested when following the redirect to destination, without query and
+// fragment. A path is requested on every hostname of the Pull Zone, an
+// absolute URL only if its host is a hostname of the Pull Zone. Its path is
+// then normalized to "/" if it is empty.
+func redirectMapDestinationURLs(destination string, hostnames []string) []string {
+	destination = strings.SplitN(strings.SplitN(destination, "#", 2)[0], "?", 2)[0]
+
+	if strings.HasPrefix(destination, "/") {
+		res := make([]string, 0, 2*len(hostnames))
+		for _, hostname := range hostnames {
+			res = append(res, "http://"+hostname+destination, "https://"+hostname+destination)
+		}
+
+		return res
+	}
+
+	u, err := url.Parse(destination)
+	if err != nil || !strSliceContainsFold(hostnames, u.Hostname()) {
+		return nil
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	return []string{u.Scheme + "://" + u.Host + path}
+}
+
+// redirectMapPatternsMatch returns true if one of the URL trigger patterns
+// matches the URL. A "*" in a pattern matches any number of characters,
+// the comparison is case-insensitive.
+func redirectMapPatternsMatch(patterns []string, u string) bool {
+	u = strings.ToLower(u)
+
+	for _, pattern := range patterns {
+		if wildcardMatch(strings.ToLower(pattern), u) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wildcardMatch returns true if s matches pattern, a "*" in pattern matches
+// any number of characters.
+func wildcardMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	if len(parts) == 1 {
+		return s == ""
+	}
+
+	last := parts[len(parts)-1]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+
+		s = s[i+len(part):]
+	}
+
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
+
+// edgeRuleChecksum returns a checksum of the settings of the Edge Rule. Like
+// for edgeRulesEqual, the GUID and the order of triggers and pattern matches
+// do not influence the checksum.
+func edgeRuleChecksum(er *edgeRule) string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "%d|%q|%q|%d|%q|%t\n",
+		ptr.GetInt(er.ActionType),
+		ptr.GetString(er.ActionParameter1),
+		ptr.GetString(er.ActionParameter2),
+		ptr.GetInt(er.TriggerMatchingType),
+		ptr.GetString(er.Description),
+		ptr.GetBool(er.Enabled),
+	)
+	fmt.Fprintln(h, edgeRuleTriggersKey(er.Triggers))
+	fmt.Fprintln(h, edgeRuleActionsKey(er.ExtraActions))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func intSliceContains(ints []int, i int) bool {
+	for _, elem := range ints {
+		if elem == i {
+			return true
+		}
+	}
+
+	return false
+}
+
+func strSliceContainsFold(strs []string, s string) bool {
+	for _, elem := range strs {
+		if strings.EqualFold(elem, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func intSliceJoin(ints []int, sep string) string {
+	strs := make([]string, 0, len(ints))
+	for _, i := range ints {
+		strs = append(strs, strconv.Itoa(i))
+	}
+
+	return strings.Join(strs, sep)
+}
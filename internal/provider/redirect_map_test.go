@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+)
+
+func TestRedirectMapFromCSV(t *testing.T) {
+	const csv = `source,destination,status_code
+# moved pages
+/old, /new
+/tmp,https://example.com/tmp,302
+/empty-status,/new,
+`
+
+	redirects, err := redirectMapFromCSV(strings.NewReader(csv), 301)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []redirect{
+		{source: "/old", destination: "/new", statusCode: 301, line: 3},
+		{source: "/tmp", destination: "https://example.com/tmp", statusCode: 302, line: 4},
+		{source: "/empty-status", destination: "/new", statusCode: 301, line: 5},
+	}
+
+	if len(redirects) != len(want) {
+		t.Fatalf("expected %d redirects, got %d", len(want), len(redirects))
+	}
+
+	for i := range want {
+		if *redirects[i] != want[i] {
+			t.Errorf("redirect %d: expected %+v, got %+v", i, want[i], *redirects[i])
+		}
+	}
+}
+
+func TestRedirectMapFromCSVErrors(t *testing.T) {
+	testcases := map[string]string{
+		"too many columns":  "/a,/b,301,x\n",
+		"too few columns":   "/a\n",
+		"invalid status":    "/a,/b,moved\n",
+		"unbalanced quotes": "\"/a,/b\n",
+	}
+
+	for name, csv := range testcases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := redirectMapFromCSV(strings.NewReader(csv), 301); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRedirectMapValidate(t *testing.T) {
+	testcases := []struct {
+		name      string
+		redirects []*redirect
+		wantErrs  []string
+	}{
+		{
+			name: "valid",
+			redirects: []*redirect{
+				{source: "/a", destination: "/b", statusCode: 301},
+				{source: "/b", destination: "/c", statusCode: 301},
+				{source: "https://example.com/d", destination: "https://example.com/", statusCode: 308},
+			},
+		},
+		{
+			name: "duplicate source",
+			redirects: []*redirect{
+				{source: "/a", destination: "/b", statusCode: 301, line: 1},
+				{source: "/a", destination: "/c", statusCode: 301, line: 2},
+			},
+			wantErrs: []string{`line 2: duplicate source "/a", it is already defined in line 1`},
+		},
+		{
+			name: "self loop",
+			redirects: []*redirect{
+				{source: "/a", destination: "/a", statusCode: 301},
+			},
+			wantErrs: []string{"redirect loop: /a -> /a"},
+		},
+		{
+			name: "loop",
+			redirects: []*redirect{
+				{source: "/a", destination: "/b", statusCode: 301},
+				{source: "/b", destination: "/c", statusCode: 301},
+				{source: "/c", destination: "/a", statusCode: 301},
+				{source: "/d", destination: "/a", statusCode: 301},
+			},
+			wantErrs: []string{"redirect loop: /a -> /b -> /c -> /a"},
+		},
+		{
+			name: "path is not matched as suffix",
+			redirects: []*redirect{
+				{source: "/b", destination: "/x/b", statusCode: 301},
+				{source: "/x/b", destination: "/c", statusCode: 301},
+			},
+		},
+		{
+			name: "absolute destination on a pull zone hostname",
+			redirects: []*redirect{
+				{source: "/a", destination: "https://zone.b-cdn.net/b?from=a", statusCode: 301},
+				{source: "/b", destination: "/a", statusCode: 301},
+			},
+			wantErrs: []string{"redirect loop: /a -> /b -> /a"},
+		},
+		{
+			name: "path destination to an absolute source",
+			redirects: []*redirect{
+				{source: "https://www.example.com/a", destination: "/b", statusCode: 301},
+				{source: "/b", destination: "https://WWW.Example.com/a", statusCode: 301},
+			},
+			wantErrs: []string{"redirect loop: https://www.example.com/a -> /b -> https://www.example.com/a"},
+		},
+		{
+			name: "absolute destination on another host",
+			redirects: []*redirect{
+				{source: "/a", destination: "https://other.example.com/a", statusCode: 301},
+			},
+		},
+		{
+			name: "invalid entries",
+			redirects: []*redirect{
+				{source: "/a/*", destination: "/b", statusCode: 301},
+				{source: "a", destination: "b", statusCode: 200},
+			},
+			wantErrs: []string{
+				`redirect "/a/*": source "/a/*" must not contain wildcards`,
+				`redirect "a": source "a" must start with`,
+				`redirect "a": destination "b" must start with`,
+				`redirect "a": unsupported status code 200`,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := redirectMapValidate(tc.redirects, []string{"zone.b-cdn.net", "www.example.com"})
+
+			if len(errs) != len(tc.wantErrs) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tc.wantErrs), len(errs), errs)
+			}
+
+			for i, want := range tc.wantErrs {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d: expected %q to contain %q", i, errs[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRedirectMapCompile(t *testing.T) {
+	const sourcesPerRule = redirectMapMaxTriggers * redirectMapMaxPatternMatches
+
+	var redirects []*redirect
+	for i := 0; i < 2*sourcesPerRule+1; i++ {
+		redirects = append(redirects, &redirect{source: fmt.Sprintf("/a/%d", i), destination: "/a", statusCode: 301})
+	}
+
+	redirects = append(redirects,
+		&redirect{source: "/b/1", destination: "/b", statusCode: 301},
+		&redirect{source: "/b/2", destination: "/b", statusCode: 302},
+	)
+
+	rules := redirectMapCompile(redirects, []string{"zone.b-cdn.net"}, "prefix: ")
+
+	for _, k := range []string{"301 /b #1", "302 /b #1"} {
+		if _, exists := rules[k]; !exists {
+			t.Errorf("edge rule %q is missing", k)
+		}
+	}
+
+	if _, exists := rules["301 /a #1"]; exists {
+		t.Error("the redirects to /a were not split into multiple edge rules")
+	}
+
+	sources := 0
+
+	for k, r := range rules {
+		if got := ptr.GetString(r.Description); got != "prefix: "+k {
+			t.Errorf("edge rule %q: unexpected description %q", k, got)
+		}
+
+		if len(r.Triggers) > redirectMapMaxTriggers {
+			t.Errorf("edge rule %q: has %d triggers", k, len(r.Triggers))
+		}
+
+		for _, tr := range r.Triggers {
+			if len(tr.PatternMatches) > redirectMapMaxPatternMatches {
+				t.Errorf("edge rule %q: trigger has %d pattern matches", k, len(tr.PatternMatches))
+			}
+
+			sources += len(tr.PatternMatches)
+		}
+	}
+
+	if sources != len(redirects) {
+		t.Errorf("expected %d sources in the edge rules, got %d", len(redirects), sources)
+	}
+
+	if got := ptr.GetString(rules["302 /b #1"].ActionParameter2); got != "302" {
+		t.Errorf("expected status code 302 as action parameter 2, got %q", got)
+	}
+}
+
+func TestRedirectMapCompilePatterns(t *testing.T) {
+	rules := redirectMapCompile([]*redirect{
+		{source: "/old-path", destination: "/new", statusCode: 301},
+		{source: "https://example.com/old", destination: "/new", statusCode: 301},
+	}, []string{"www.example.com", "zone.b-cdn.net"}, "")
+
+	r, exists := rules["301 /new #1"]
+	if !exists {
+		t.Fatalf("edge rule %q is missing, got: %v", "301 /new #1", rules)
+	}
+
+	want := []string{"http*://www.example.com/old-path", "http*://zone.b-cdn.net/old-path", "https://example.com/old"}
+	if len(r.Triggers) != 1 || !reflect.DeepEqual(r.Triggers[0].PatternMatches, want) {
+		t.Errorf("expected a trigger with the patterns %q, got: %+v", want, r.Triggers)
+	}
+}
+
+func TestRedirectMapCompileSplitsPatternsOfHostnames(t *testing.T) {
+	hostnames := []string{"a.example.com", "b.example.com", "c.example.com"}
+
+	var redirects []*redirect
+	for i := 0; i < 200; i++ {
+		redirects = append(redirects, &redirect{source: fmt.Sprintf("/a/%d", i), destination: "/a", statusCode: 301})
+	}
+
+	patterns := 0
+	for k, r := range redirectMapCompile(redirects, hostnames, "") {
+		n := 0
+		for _, tr := range r.Triggers {
+			n += len(tr.PatternMatches)
+		}
+
+		if n > redirectMapMaxTriggers*redirectMapMaxPatternMatches {
+			t.Errorf("edge rule %q: has %d patterns", k, n)
+		}
+
+		patterns += n
+	}
+
+	if patterns != len(redirects)*len(hostnames) {
+		t.Errorf("expected a pattern per source and hostname, got %d patterns", patterns)
+	}
+}
+
+func TestRedirectMapSourcePatternsMatch(t *testing.T) {
+	hostnames := []string{"zone.b-cdn.net"}
+
+	testcases := []struct {
+		source string
+		url    string
+		want   bool
+	}{
+		{source: "/foo", url: "https://zone.b-cdn.net/foo", want: true},
+		{source: "/foo", url: "http://zone.b-cdn.net/foo", want: true},
+		{source: "/foo", url: "https://ZONE.b-cdn.net/foo", want: true},
+		{source: "/foo", url: "https://zone.b-cdn.net/a/foo", want: false},
+		{source: "/foo", url: "https://zone.b-cdn.net/foo/bar", want: false},
+		{source: "/foo", url: "https://other.example.com/foo", want: false},
+		{source: "https://example.com/foo", url: "https://example.com/foo", want: true},
+		{source: "https://example.com/foo", url: "http://example.com/foo", want: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.source+" "+tc.url, func(t *testing.T) {
+			if got := redirectMapPatternsMatch(redirectMapSourcePatterns(tc.source, hostnames), tc.url); got != tc.want {
+				t.Errorf("expected match %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	testcases := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{pattern: "abc", s: "abc", want: true},
+		{pattern: "abc", s: "abcd", want: false},
+		{pattern: "*", s: "", want: true},
+		{pattern: "a*c", s: "abbc", want: true},
+		{pattern: "a*c", s: "ac", want: true},
+		{pattern: "a*c*e", s: "abcde", want: true},
+		{pattern: "a*b*b", s: "ab", want: false},
+		{pattern: "*/a", s: "https://host/b/a", want: true},
+		{pattern: "a*", s: "b", want: false},
+	}
+
+	for _, tc := range testcases {
+		if got := wildcardMatch(tc.pattern, tc.s); got != tc.want {
+			t.Errorf("wildcardMatch(%q, %q): expected %t, got %t", tc.pattern, tc.s, tc.want, got)
+		}
+	}
+}
+
+func TestRedirectMapCompileIsStable(t *testing.T) {
+	const sourcesPerRule = redirectMapMaxTriggers * redirectMapMaxPatternMatches
+
+	var redirects []*redirect
+	for i := 0; i < 3*sourcesPerRule; i++ {
+		redirects = append(redirects, &redirect{source: fmt.Sprintf("/a/%d", i), destination: "/a", statusCode: 301})
+	}
+
+	checksums := func(rules map[string]*addOrUpdateEdgeRuleOptions) map[string]string {
+		res := make(map[string]string, len(rules))
+		for k, r := range rules {
+			res[k] = edgeRuleChecksum(edgeRuleFromOptions(r))
+		}
+
+		return res
+	}
+
+	before := checksums(redirectMapCompile(redirects, []string{"zone.b-cdn.net"}, ""))
+	after := checksums(redirectMapCompile(append(redirects,
+		&redirect{source: "/a/new", destination: "/a", statusCode: 301},
+	), []string{"zone.b-cdn.net"}, ""))
+
+	if len(before) != len(after) {
+		t.Fatalf("expected %d edge rules after adding a redirect, got %d", len(before), len(after))
+	}
+
+	changed := 0
+	for k, checksum := range before {
+		if after[k] != checksum {
+			changed++
+		}
+	}
+
+	if changed != 1 {
+		t.Errorf("expected adding a redirect to change 1 edge rule, %d changed", changed)
+	}
+}
+
+func TestRedirectMapBuckets(t *testing.T) {
+	var sources []string
+	for i := 0; i < 100; i++ {
+		sources = append(sources, fmt.Sprintf("/%d", i))
+	}
+
+	buckets := redirectMapBuckets(sources, 10)
+
+	n := 0
+	for bucket, bucketSources := range buckets {
+		if len(bucketSources) == 0 || len(bucketSources) > 10 {
+			t.Errorf("bucket %d has %d sources", bucket, len(bucketSources))
+		}
+
+		n += len(bucketSources)
+	}
+
+	if n != len(sources) {
+		t.Errorf("expected %d sources in the buckets, got %d", len(sources), n)
+	}
+
+	if buckets := redirectMapBuckets(sources[:10], 10); len(buckets[1]) != 10 {
+		t.Errorf("expected all sources in bucket 1 when they fit, got: %v", buckets)
+	}
+}
+
+func TestEdgeRuleChecksum(t *testing.T) {
+	a := testEdgeRule("a", "rule", "/a", "/b")
+
+	if edgeRuleChecksum(a) != edgeRuleChecksum(testEdgeRule("b", "rule", "/b", "/a")) {
+		t.Error("checksums of edge rules with different guids and pattern order differ")
+	}
+
+	if edgeRuleChecksum(a) == edgeRuleChecksum(testEdgeRule("a", "rule", "/a", "/c")) {
+		t.Error("checksums of edge rules with different pattern matches are equal")
+	}
+}
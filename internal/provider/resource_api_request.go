@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyAPIRequestMethod       = "method"
+	keyAPIRequestPath         = "path"
+	keyAPIRequestBody         = "body"
+	keyAPIRequestCreatePath   = "create_path"
+	keyAPIRequestReadPath     = "read_path"
+	keyAPIRequestUpdatePath   = "update_path"
+	keyAPIRequestUpdateMethod = "update_method"
+	keyAPIRequestDeletePath   = "delete_path"
+	keyAPIRequestDeleteMethod = "delete_method"
+	keyAPIRequestIDPath       = "id_path"
+	keyAPIRequestResponse     = "response"
+)
+
+// apiRequestIDPlaceholder is replaced in the read, update and delete paths
+// with the ID of the resource.
+const apiRequestIDPlaceholder = "{id}"
+
+var apiRequestMethods = []string{
+	http.MethodDelete,
+	http.MethodGet,
+	http.MethodPatch,
+	http.MethodPost,
+	http.MethodPut,
+}
+
+var validateAPIRequestMethod = validation.ToDiagFunc(validation.StringInSlice(apiRequestMethods, false))
+
+// apiRequestDataSourceMethods are the HTTP methods that are supported by the
+// data source, they must not change anything.
+var apiRequestDataSourceMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+}
+
+func resourceAPIRequest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAPIRequestCreate,
+		ReadContext:   resourceAPIRequestRead,
+		UpdateContext: resourceAPIRequestUpdate,
+		DeleteContext: resourceAPIRequestDelete,
+
+		Description: "Sends requests to arbitrary endpoints of the bunny.net API. " +
+			"It is intended for features that are not supported by a dedicated resource. " +
+			"The requests are authenticated with the API key of the provider.\n\n" +
+			"The read, update and delete paths can contain the placeholder `" + apiRequestIDPlaceholder +
+			"`, it is replaced with the ID of the resource.",
+
+		CustomizeDiff: customdiff.All(
+			// without an update path, changing the body can only be
+			// applied by recreating the resource
+			customdiff.ForceNewIf(keyAPIRequestBody, func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.Get(keyAPIRequestUpdatePath).(string) == ""
+			}),
+		),
+
+		Schema: map[string]*schema.Schema{
+			keyAPIRequestPath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the API endpoint, relative to the bunny.net API base URL. It is used for all requests for that no separate path is set.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestMethod: {
+				Type:             schema.TypeString,
+				Description:      "The HTTP method of the create request.\nValid values: " + strings.Join(apiRequestMethods, ", "),
+				Optional:         true,
+				Default:          http.MethodPost,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAPIRequestMethod,
+			},
+			keyAPIRequestBody: {
+				Type:             schema.TypeString,
+				Description:      "The JSON encoded body of the create and update requests.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: diffSuppressEquivalentJSONValue,
+			},
+			keyAPIRequestCreatePath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the create request. Defaults to `" + keyAPIRequestPath + "`.",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestReadPath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the GET request that retrieves the current state. If not set, the response is only updated by create and update requests.",
+				Optional:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestUpdatePath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the update request. If not set, changing `" + keyAPIRequestBody + "` recreates the resource.",
+				Optional:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestUpdateMethod: {
+				Type:             schema.TypeString,
+				Description:      "The HTTP method of the update request.\nValid values: " + strings.Join(apiRequestMethods, ", "),
+				Optional:         true,
+				Default:          http.MethodPost,
+				ValidateDiagFunc: validateAPIRequestMethod,
+			},
+			keyAPIRequestDeletePath: {
+				Type:             schema.TypeString,
+				Description:      "The path of the delete request. If not set, destroying the resource only removes it from the Terraform state.",
+				Optional:         true,
+				ValidateDiagFunc: validateAPIRequestPath,
+			},
+			keyAPIRequestDeleteMethod: {
+				Type:             schema.TypeString,
+				Description:      "The HTTP method of the delete request.\nValid values: " + strings.Join(apiRequestMethods, ", "),
+				Optional:         true,
+				Default:          http.MethodDelete,
+				ValidateDiagFunc: validateAPIRequestMethod,
+			},
+			keyAPIRequestIDPath: {
+				Type: schema.TypeString,
+				Description: "The dot-separated path of the field in the create response that contains the ID of the resource, e.g. `Id` or `Items.0.Id`. " +
+					"If not set, the create path is used as ID.",
+				Optional: true,
+				ForceNew: true,
+			},
+			keyAPIRequestResponse: {
+				Type:        schema.TypeString,
+				Description: "The JSON encoded body of the last response.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceAPIRequestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+
+	path := d.Get(keyAPIRequestCreatePath).(string)
+	if path == "" {
+		path = d.Get(keyAPIRequestPath).(string)
+	}
+
+	resp, err := apiRequestSend(ctx, clt, d.Get(keyAPIRequestMethod).(string), path, d.Get(keyAPIRequestBody).(string))
+	if err != nil {
+		return diagsErrFromErr("create request failed", err)
+	}
+
+	id := path
+	idPath := d.Get(keyAPIRequestIDPath).(string)
+	var idErr error
+	if idPath != "" {
+		id, idErr = jsonValueAtPath(resp, idPath)
+		if idErr != nil {
+			// the object was created, store the response to not lose track
+			// of it
+			id = path
+		}
+	}
+
+	d.SetId(id)
+
+	if err := d.Set(keyAPIRequestResponse, string(resp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if idErr != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("could not retrieve id from create response via %s: %s", keyAPIRequestIDPath, idErr),
+			Detail: fmt.Sprintf("The object was created, the resource is stored with the ID %q and the create response in %s. "+
+				"It is marked as tainted and will be replaced on the next apply.",
+				id, keyAPIRequestResponse,
+			),
+		}}
+	}
+
+	return resourceAPIRequestRead(ctx, d, meta)
+}
+
+func resourceAPIRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+
+	path := d.Get(keyAPIRequestReadPath).(string)
+	if path == "" {
+		return nil
+	}
+
+	resp, err := apiRequestSend(ctx, clt, http.MethodGet, apiRequestPathWithID(path, d.Id()), "")
+	if err != nil {
+		return diagsErrFromErr("read request failed", err)
+	}
+
+	if err := d.Set(keyAPIRequestResponse, string(resp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAPIRequestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+
+	path := d.Get(keyAPIRequestUpdatePath).(string)
+	if path == "" || !d.HasChange(keyAPIRequestBody) {
+		return resourceAPIRequestRead(ctx, d, meta)
+	}
+
+	resp, err := apiRequestSend(ctx, clt,
+		d.Get(keyAPIRequestUpdateMethod).(string),
+		apiRequestPathWithID(path, d.Id()),
+		d.Get(keyAPIRequestBody).(string),
+	)
+	if err != nil {
+		return diagsErrFromErr("update request failed", err)
+	}
+
+	if err := d.Set(keyAPIRequestResponse, string(resp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAPIRequestRead(ctx, d, meta)
+}
+
+func resourceAPIRequestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+
+	path := d.Get(keyAPIRequestDeletePath).(string)
+	if path == "" {
+		logger.Infof("%s of api request resource %q is not set, only removing it from the state", keyAPIRequestDeletePath, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	_, err := apiRequestSend(ctx, clt, d.Get(keyAPIRequestDeleteMethod).(string), apiRequestPathWithID(path, d.Id()), "")
+	if err != nil {
+		return diagsErrFromErr("delete request failed", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// apiRequestSend sends a request with the given method to path.
+// body must be empty or a JSON encoded value.
+// The response body is returned in compact form.
+func apiRequestSend(ctx context.Context, clt *apiClient, method, path, body string) ([]byte, error) {
+	var reqBody interface{}
+	if body != "" {
+		reqBody = json.RawMessage(body)
+	}
+
+	resp, err := clt.doRaw(ctx, method, path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) == 0 {
+		return resp, nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, resp); err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// apiRequestPathWithID returns path with the ID placeholder replaced by the
+// path escaped id.
+func apiRequestPathWithID(path, id string) string {
+	return strings.ReplaceAll(path, apiRequestIDPlaceholder, url.PathEscape(id))
+}
+
+// validateAPIRequestPath ensures that the value is a relative path, to
+// prevent that the API key is sent to another host.
+var validateAPIRequestPath = validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if v == "" {
+		return nil, []error{fmt.Errorf("%s must not be empty", k)}
+	}
+
+	u, err := url.Parse(strings.ReplaceAll(v, apiRequestIDPlaceholder, "id"))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid path: %w", k, err)}
+	}
+
+	if u.Scheme != "" || u.Host != "" || strings.HasPrefix(v, "//") {
+		return nil, []error{fmt.Errorf("%s must be a path relative to the bunny.net API base URL, got: %q", k, v)}
+	}
+
+	return nil, nil
+})
+
+// jsonValueAtPath returns the value of the field in the JSON document buf
+// that is referenced by the dot-separated path. Numeric path elements are
+// used as array indexes.
+// The value must be a string, number or boolean.
+func jsonValueAtPath(buf []byte, path string) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("could not parse JSON: %w", err)
+	}
+
+	for _, elem := range strings.Split(path, ".") {
+		switch cur := v.(type) {
+		case map[string]interface{}:
+			next, exists := cur[elem]
+			if !exists {
+				return "", fmt.Errorf("field %q of path %q does not exist", elem, path)
+			}
+			v = next
+
+		case []interface{}:
+			idx, err := strconv.Atoi(elem)
+			if err != nil || idx < 0 || idx >= len(cur) {
+				return "", fmt.Errorf("index %q of path %q does not exist", elem, path)
+			}
+			v = cur[idx]
+
+		default:
+			return "", fmt.Errorf("element %q of path %q references a field of a value that is not an object or array", elem, path)
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		if val == "" {
+			return "", errors.New("value is an empty string")
+		}
+		return val, nil
+	case json.Number:
+		return val.String(), nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	default:
+		return "", fmt.Errorf("value of path %q is not a string, number or boolean, got: %T", path, v)
+	}
+}
+
+func diffSuppressEquivalentJSONValue(_, old, new string, _ *schema.ResourceData) bool {
+	var oldV, newV interface{}
+
+	if err := json.Unmarshal([]byte(old), &oldV); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(new), &newV); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldV, newV)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAPIRequest_pullZone(t *testing.T) {
+	const resourceName = "bunny_api_request.testpz"
+	pzName := randResourceName()
+
+	tf := func(originURL string) string {
+		return fmt.Sprintf(`
+resource "bunny_api_request" "testpz" {
+	path = "pullzone"
+	body = jsonencode({
+		Name = "%s"
+		OriginUrl = "%s"
+	})
+	id_path = "Id"
+
+	read_path = "pullzone/{id}"
+	update_path = "pullzone/{id}"
+	delete_path = "pullzone/{id}"
+}`, pzName, originURL)
+	}
+
+	checkOriginURL := func(originURL string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			strID, err := idFromState(s, resourceName)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(strID, 10, 64)
+			if err != nil {
+				return fmt.Errorf("id is not an integer: %w", err)
+			}
+
+			pz, err := newAPIClient().PullZone.Get(context.Background(), id)
+			if err != nil {
+				return fmt.Errorf("fetching pull-zone from api failed: %w", err)
+			}
+
+			return stringsAreEqual(originURL, pz.OriginURL)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf("https://bunny.net"),
+				Check: resource.ComposeTestCheckFunc(
+					checkOriginURL("https://bunny.net"),
+					resource.TestCheckResourceAttrSet(resourceName, "response"),
+				),
+			},
+			{
+				Config: tf("https://terraform.io"),
+				Check:  checkOriginURL("https://terraform.io"),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccAPIRequest_absoluteURLsAreRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "bunny_api_request" "test" {
+	path = "https://example.com/pullzone"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be a path relative to the bunny.net API base URL"),
+			},
+		},
+	})
+}
+
+func TestAccAPIRequestDataSource_regions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "bunny_api_request" "regions" {
+	path = "region"
+}`,
+				Check: resource.TestMatchResourceAttr("data.bunny_api_request.regions", "response", regexp.MustCompile(`^\[.+\]$`)),
+			},
+		},
+	})
+}
+
+func TestAccAPIRequestDataSource_modifyingMethodsAreRejected(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "bunny_api_request" "test" {
+	path = "pullzone"
+	method = "POST"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected method to be one of \["GET" "HEAD"\]`),
+			},
+		},
+	})
+}
+
+func TestAPIRequestCreateKeepsObjectWithoutID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Name":"test"}`))
+	}))
+	defer srv.Close()
+
+	clt := newBunnyAPIClient("key", "test")
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clt.baseURL = baseURL
+
+	d := schema.TestResourceDataRaw(t, resourceAPIRequest().Schema, map[string]interface{}{
+		keyAPIRequestPath:   "pullzone",
+		keyAPIRequestMethod: http.MethodPost,
+		keyAPIRequestIDPath: "Id",
+	})
+
+	diags := resourceAPIRequestCreate(context.Background(), d, &providerMeta{api: clt})
+	if !diags.HasError() {
+		t.Fatal("expected an error when the id is missing in the response")
+	}
+
+	if d.Id() != "pullzone" {
+		t.Errorf("expected the create path to be stored as id, got: %q", d.Id())
+	}
+
+	if resp := d.Get(keyAPIRequestResponse).(string); resp != `{"Name":"test"}` {
+		t.Errorf("expected the create response to be stored, got: %q", resp)
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"sync"
 
+	ptr "github.com/AlekSi/pointer"
 	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -15,14 +15,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// edgeRuleUpdateMu serializes create/update operations of edge rules. The edge
-// rule api endpoint is currently not concurrency safe. If multiple
-// create/update operations are done In Parallel, it might happen that changes
-// get lost.
-// Serializing crete/update operation reduces the chance that concurrency
-// issues occur.
-var edgeRuleUpdateMu sync.Mutex
-
 const (
 	keyEdgeRuleActionParameter1           = "action_parameter_1"
 	keyEdgeRuleActionParameter2           = "action_parameter_2"
@@ -38,8 +30,43 @@ const (
 	keyEdgeRuleTriggers                   = "trigger"
 )
 
+var resourceEdgeRuleTrigger = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyEdgeRuleTriggerType: {
+			Type: schema.TypeString,
+			Description: "The type of the Trigger. Types that are not supported by the provider are read as their number.\nValid values: " +
+				strings.Join(edgeRuleTriggerTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(edgeRuleTriggerTypeKeys, false),
+			),
+		},
+		keyEdgeRuleTriggerPatternMatches: {
+			Type:        schema.TypeSet,
+			Description: "The list of pattern matches that will trigger the edge rule. The format is validated for the types country_code (ISO 3166-1 alpha-2 code), random_chance (percentage 0-100), remote_ip (IP address or CIDR network), request_method (HTTP method), status_code (100-599), origin_retry_attempt_count (number of retries) and url (must start with \"*\", \"/\", \"http://\" or \"https://\").",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		keyEdgeRuleTriggerPatternMatchingType: {
+			Type: schema.TypeString,
+			Description: "The type of pattern matching.\nValid values: " +
+				strings.Join(edgeRuleMatchingTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(edgeRuleMatchingTypeKeys, false),
+			),
+		},
+
+		keyEdgeRuleTriggerParameter1: {
+			Type:        schema.TypeString,
+			Description: "The trigger parameter 1, it is required for the types that have a parameter: " + typeParameterDocs(edgeRuleTriggerParameter1Docs) + ".",
+			Optional:    true,
+		},
+	},
+}
+
 func resourceEdgeRule() *schema.Resource {
-	return &schema.Resource{
+	res := &schema.Resource{
 		CreateContext: resourceEdgeRuleCreate,
 		ReadContext:   resourceEdgeRuleRead,
 		DeleteContext: resourceEdgeRuleDelete,
@@ -48,6 +75,11 @@ func resourceEdgeRule() *schema.Resource {
 			StateContext: resourceEdgeRuleImport,
 		},
 
+		CustomizeDiff: customizeDiffAll(
+			edgeRuleActionDiff,
+			edgeRuleTriggersValidate,
+		),
+
 		Schema: map[string]*schema.Schema{
 			keyEdgeRulePullZoneID: {
 				Type:        schema.TypeInt,
@@ -57,61 +89,40 @@ func resourceEdgeRule() *schema.Resource {
 			},
 			keyEdgeRuleActionType: {
 				Type: schema.TypeString,
-				Description: "The action type of the Edge Rule.\nValid values: " +
+				Description: "The action type of the Edge Rule. Instead of setting it, the typed block for the action can be used. Types that are not supported by the provider are read as their number.\nValid values: " +
 					strings.Join(edgeRuleActionTypeKeys, ", "),
 				ValidateDiagFunc: validation.ToDiagFunc(
 					validation.StringInSlice(edgeRuleActionTypeKeys, false),
 				),
-				Required: true,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: edgeRuleActionKeys(),
 			},
 			keyEdgeRuleActionParameter1: {
-				Type:        schema.TypeString,
-				Description: "The Action parameter 1. The value depends on other parameters of the edge rule.",
-				Optional:    true,
+				Type:          schema.TypeString,
+				Description:   "The Action parameter 1. The value depends on the action type: " + typeParameterDocs(edgeRuleActionParameter1Docs) + ".",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: edgeRuleActionBlockKeys,
 			},
 			keyEdgeRuleActionParameter2: {
-				Type:        schema.TypeString,
-				Description: "The Action parameter 2. The value depends on other parameters of the edge rule.",
-				Optional:    true,
+				Type:          schema.TypeString,
+				Description:   "The Action parameter 2. The value depends on the action type: " + typeParameterDocs(edgeRuleActionParameter2Docs) + ".",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: edgeRuleActionBlockKeys,
 			},
 			keyEdgeRuleTriggers: {
 				Type:     schema.TypeSet,
 				Required: true,
 				MaxItems: 5, // otherwise the API returns the error: Maximum 5 condition are allowed per rule.
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						keyEdgeRuleTriggerType: {
-							Type: schema.TypeString,
-							Description: "The type of the Trigger.\nValid values: " +
-								strings.Join(edgeRuleTriggerTypeKeys, ", "),
-							Required: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(edgeRuleTriggerTypeKeys, false),
-							),
-						},
-						keyEdgeRuleTriggerPatternMatches: {
-							Type:        schema.TypeSet,
-							Description: "The list of pattern matches that will trigger the edge rule.",
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-						},
-						keyEdgeRuleTriggerPatternMatchingType: {
-							Type: schema.TypeString,
-							Description: "The type of pattern matching.\nValid values: " +
-								strings.Join(edgeRuleMatchingTypeKeys, ", "),
-							Required: true,
-							ValidateDiagFunc: validation.ToDiagFunc(
-								validation.StringInSlice(edgeRuleMatchingTypeKeys, false),
-							),
-						},
-
-						keyEdgeRuleTriggerParameter1: {
-							Type:        schema.TypeString,
-							Description: "The trigger parameter 1. The value depends on the type of trigger.",
-							Optional:    true,
-						},
-					},
-				},
+				Elem:     resourceEdgeRuleTrigger,
+			},
+			keyEdgeRuleExtraActions: {
+				Type:        schema.TypeList,
+				Description: "Additional actions that are executed in the given order after the action defined by action_type or the typed action block.",
+				Optional:    true,
+				Elem:        resourceEdgeRuleExtraAction,
 			},
 			keyEdgeRuleTriggerMatchingType: {
 				Type:        schema.TypeString,
@@ -123,7 +134,8 @@ func resourceEdgeRule() *schema.Resource {
 			},
 			keyEdgeRuleDescription: {
 				Type:        schema.TypeString,
-				Description: "The description of the Edge Rule. This field is used internally by Terraform bunny-provider.",
+				Description: "The description of the Edge Rule.",
+				Optional:    true,
 				Computed:    true,
 			},
 			keyEdgeRuleEnabled: {
@@ -134,70 +146,183 @@ func resourceEdgeRule() *schema.Resource {
 			},
 		},
 	}
-}
 
-// findEdgeRuleGUID retrieves the Pull Zone from the bunny API and returns the guid of the first found edge rule that matches the Description.
-func findEdgeRuleGUID(ctx context.Context, clt *bunny.Client, pullZoneID int64, description string) (string, error) {
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
-	if err != nil {
-		return "", fmt.Errorf("retrieving pull zone failed: %w", err)
+	for key, sch := range edgeRuleActionSchemas() {
+		res.Schema[key] = sch
 	}
 
-	for _, edgeRule := range pz.EdgeRules {
-		if edgeRule.Description != nil && *edgeRule.Description == description {
-			if edgeRule.GUID == nil {
-				return "", errors.New("found edge rule with matching description but guid is nil")
-			}
-
-			return *edgeRule.GUID, nil
-		}
-	}
-
-	return "", fmt.Errorf("pull zone has no edge rule rule with internal identifier in description")
+	return res
 }
 
 func resourceEdgeRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
-
-	// The bunny API endpoint does not return the ID of a newly created
-	// Edge Rule.  To be able to identify the created edge rule uniquely
-	// (including distinguishing them from edge-rules with the same
-	// settings created in parallel via e.g. the UI), we store an own ID in
-	// the description field.  The ID is only used once during creation to
-	// initially find the created Edge Rule. After it was found the GUID is
-	// used for identification. The description could be safely overwritten
-	internalEdgeRuleID := "terraform-provider-bunny id: " + uuid.New().String()
-	if err := d.Set(keyEdgeRuleDescription, internalEdgeRuleID); err != nil {
-		return diag.FromErr(err)
-	}
+	pm := meta.(*providerMeta)
 
 	opts, err := edgeRuleFromResource(d)
 	if err != nil {
-		return diagsErrFromErr("setting description failed", err)
+		return diag.FromErr(err)
 	}
 
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	edgeRuleUpdateMu.Lock()
-	defer edgeRuleUpdateMu.Unlock()
-	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	before, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
 	if err != nil {
-		return diagsErrFromErr("creating edge rule failed", err)
+		return diagsErrFromErr("retrieving pull zone failed", err)
 	}
 
-	guid, err := findEdgeRuleGUID(ctx, clt, pullZoneID, internalEdgeRuleID)
-	if err != nil {
-		return diagsErrFromErr(
-			fmt.Sprintf("edge rule (description: %q) created successfully, looking up its guid failed", internalEdgeRuleID), err)
+	guid, _, diags := edgeRuleAddVerified(ctx, pm, pullZoneID, before, opts)
+	if guid == "" {
+		return diags
 	}
 
 	d.SetId(guid)
 
-	return nil
+	if diags.HasError() {
+		return createFailed(ctx, d, meta, "edge rule", diags,
+			func(ctx context.Context) error {
+				return pm.client.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid)
+			},
+		)
+	}
+
+	return diags
+}
+
+// edgeRuleMarkerPrefix is the prefix of the descriptions returned by
+// newEdgeRuleMarker.
+const edgeRuleMarkerPrefix = "terraform-provider-bunny id: "
+
+// newEdgeRuleMarker returns a unique description, it is used by
+// edgeRuleAddVerified to claim a created Edge Rule.
+func newEdgeRuleMarker() string {
+	return edgeRuleMarkerPrefix + uuid.New().String()
+}
+
+// edgeRuleAddVerified adds the Edge Rule opts to the Pull Zone and verifies
+// it via edgeRuleWriteVerified. It returns the GUID of the new Edge Rule and
+// the Edge Rules of the Pull Zone afterwards.
+//
+// The bunny API endpoint does not return the ID of a newly created Edge
+// Rule. The created Edge Rule is identified by comparing the Edge Rules
+// before and after it was created, see createdEdgeRuleGUID.
+// If multiple new Edge Rules have the settings of opts, one of them is
+// claimed by writing a unique marker as description, then the description
+// of opts is written. If an error happens after the Edge Rule was
+// identified, its GUID is returned with the error.
+func edgeRuleAddVerified(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	before []*edgeRule,
+	opts *addOrUpdateEdgeRuleOptions,
+) (string, []*edgeRule, diag.Diagnostics) {
+	after, diags := edgeRuleWriteVerified(ctx, pm, pullZoneID, before, &edgeRuleWrite{
+		action: "creating edge rule",
+		apply: func(ctx context.Context) error {
+			return pm.api.edgeRuleAddOrUpdate(ctx, pullZoneID, opts)
+		},
+		applied: func(edgeRules []*edgeRule) bool {
+			return len(createdEdgeRules(before, edgeRules, opts)) > 0
+		},
+	})
+	if diags.HasError() {
+		return "", nil, diags
+	}
+
+	guid, err := createdEdgeRuleGUID(before, after, opts)
+	if err == nil {
+		return guid, after, diags
+	}
+
+	if !errors.Is(err, errEdgeRuleCreatedAmbiguous) {
+		return "", nil, append(diags, diagsErrFromErr("edge rule created successfully, looking up its guid failed", err)...)
+	}
+
+	created := createdEdgeRules(before, after, opts)
+	guid = ptr.GetString(created[len(created)-1].GUID)
+
+	logger.Infof("pull zone %d: %d new edge rules have the settings of the created edge rule, claiming %q",
+		pullZoneID, len(created), guid)
+
+	claim := *opts
+	claim.GUID = &guid
+	claim.Description = ptr.ToString(newEdgeRuleMarker())
+
+	after, claimDiags := edgeRuleUpdateVerified(ctx, pm, pullZoneID, after, &claim)
+	diags = append(diags, claimDiags...)
+	if diags.HasError() {
+		return guid, nil, diags
+	}
+
+	withGUID := *opts
+	withGUID.GUID = &guid
+
+	after, updateDiags := edgeRuleUpdateVerified(ctx, pm, pullZoneID, after, &withGUID)
+	diags = append(diags, updateDiags...)
+	if diags.HasError() {
+		return guid, nil, diags
+	}
+
+	return guid, after, diags
+}
+
+// edgeRuleUpdateVerified sends opts to the API to update an existing Edge
+// Rule and verifies the result via edgeRuleWriteVerified. The Edge Rules of
+// the Pull Zone afterwards are returned.
+func edgeRuleUpdateVerified(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	before []*edgeRule,
+	opts *addOrUpdateEdgeRuleOptions,
+) ([]*edgeRule, diag.Diagnostics) {
+	guid := ptr.GetString(opts.GUID)
+
+	return edgeRuleWriteVerified(ctx, pm, pullZoneID, before, &edgeRuleWrite{
+		action: fmt.Sprintf("updating edge rule %q", guid),
+		guid:   guid,
+		apply: func(ctx context.Context) error {
+			return pm.api.edgeRuleAddOrUpdate(ctx, pullZoneID, opts)
+		},
+		applied: func(edgeRules []*edgeRule) bool {
+			er := edgeRuleByGUID(edgeRules, guid)
+			return er != nil && edgeRuleApplied(er, opts)
+		},
+	})
+}
+
+// edgeRuleSetEnabledVerified enables or disables the Edge Rule with the
+// guid via the Set Edge Rule Enabled endpoint and verifies the change like
+// edgeRuleWriteVerified.
+func edgeRuleSetEnabledVerified(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	before []*edgeRule,
+	guid string,
+	enabled bool,
+) diag.Diagnostics {
+	_, diags := edgeRuleWriteVerified(ctx, pm, pullZoneID, before, &edgeRuleWrite{
+		action: fmt.Sprintf("setting enabled of edge rule %q to %t", guid, enabled),
+		guid:   guid,
+		apply: func(ctx context.Context) error {
+			return pm.client.PullZone.SetEdgeRuleEnabled(ctx, pullZoneID, guid, &bunny.SetEdgeRuleEnabledOptions{
+				ID:    &pullZoneID,
+				Value: &enabled,
+			})
+		},
+		applied: func(edgeRules []*edgeRule) bool {
+			er := edgeRuleByGUID(edgeRules, guid)
+			return er != nil && ptr.GetBool(er.Enabled) == enabled
+		},
+	})
+
+	return diags
 }
 
 func resourceEdgeRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	pm := meta.(*providerMeta)
 
 	opts, err := edgeRuleFromResource(d)
 	if err != nil {
@@ -206,14 +331,22 @@ func resourceEdgeRuleUpdate(ctx context.Context, d *schema.ResourceData, meta in
 
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	edgeRuleUpdateMu.Lock()
-	defer edgeRuleUpdateMu.Unlock()
-	err = clt.PullZone.AddOrUpdateEdgeRule(ctx, pullZoneID, opts)
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	before, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("updating edge rule failed: %w", err))
+		return diagsErrFromErr("retrieving pull zone failed", err)
 	}
 
-	return nil
+	// Enabling or disabling only is done via the dedicated endpoint instead of
+	// sending the whole Edge Rule.
+	if !d.HasChangeExcept(keyEdgeRuleEnabled) {
+		return edgeRuleSetEnabledVerified(ctx, pm, pullZoneID, before, d.Id(), d.Get(keyEdgeRuleEnabled).(bool))
+	}
+
+	_, diags := edgeRuleUpdateVerified(ctx, pm, pullZoneID, before, opts)
+
+	return diags
 }
 
 func resourceEdgeRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -245,15 +378,14 @@ func resourceEdgeRuleImport(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func edgeRuleTriggerTypeToInt(triggerType string) (int, error) {
-	if k, exists := edgeRuleTriggerTypesStr[triggerType]; exists {
-		return k, nil
-	}
-
-	return -1, fmt.Errorf("unsupported trigger type type: %q", triggerType)
+	return strIntMapGetOrRaw(edgeRuleTriggerTypesStr, triggerType)
 }
 
 func edgeRuleTriggersFromResource(d *schema.ResourceData) ([]*bunny.EdgeRuleTrigger, error) {
-	triggerSet := d.Get(keyEdgeRuleTriggers).(*schema.Set)
+	return edgeRuleTriggersFromSet(d.Get(keyEdgeRuleTriggers).(*schema.Set))
+}
+
+func edgeRuleTriggersFromSet(triggerSet *schema.Set) ([]*bunny.EdgeRuleTrigger, error) {
 	if triggerSet.Len() == 0 {
 		return nil, nil
 	}
@@ -273,7 +405,7 @@ func edgeRuleTriggersFromResource(d *schema.ResourceData) ([]*bunny.EdgeRuleTrig
 			patternMatches = strSetAsSlice(val)
 		}
 
-		patternMatchingType, err := strIntMapGet(
+		patternMatchingType, err := strIntMapGetOrRaw(
 			edgeRuleMatchingTypesStr, i[keyEdgeRuleTriggerPatternMatchingType].(string),
 		)
 		if err != nil {
@@ -293,13 +425,13 @@ func edgeRuleTriggersFromResource(d *schema.ResourceData) ([]*bunny.EdgeRuleTrig
 	return res, nil
 }
 
-func edgeRuleFromResource(d *schema.ResourceData) (*bunny.AddOrUpdateEdgeRuleOptions, error) {
+func edgeRuleFromResource(d *schema.ResourceData) (*addOrUpdateEdgeRuleOptions, error) {
 	var guid *string
 	if id := d.Id(); id != "" {
 		guid = &id
 	}
 
-	actionType, err := strIntMapGet(
+	actionType, err := strIntMapGetOrRaw(
 		edgeRuleActionTypesStr,
 		d.Get(keyEdgeRuleActionType).(string),
 	)
@@ -307,7 +439,7 @@ func edgeRuleFromResource(d *schema.ResourceData) (*bunny.AddOrUpdateEdgeRuleOpt
 		return nil, fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
 	}
 
-	matchingType, err := strIntMapGet(
+	matchingType, err := strIntMapGetOrRaw(
 		edgeRuleMatchingTypesStr,
 		d.Get(keyEdgeRuleTriggerMatchingType).(string),
 	)
@@ -320,52 +452,62 @@ func edgeRuleFromResource(d *schema.ResourceData) (*bunny.AddOrUpdateEdgeRuleOpt
 		return nil, fmt.Errorf("converting edge rule triggers failed: %w", err)
 	}
 
-	return &bunny.AddOrUpdateEdgeRuleOptions{
-		GUID:                guid,
-		Enabled:             getBoolPtr(d, keyEnabled),
-		ActionType:          &actionType,
-		ActionParameter1:    getStrPtr(d, keyEdgeRuleActionParameter1),
-		ActionParameter2:    getStrPtr(d, keyEdgeRuleActionParameter2),
-		Triggers:            triggers,
-		TriggerMatchingType: &matchingType,
-		Description:         getStrPtr(d, keyEdgeRuleDescription),
+	extraActions, err := edgeRuleExtraActionsFromResource(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &addOrUpdateEdgeRuleOptions{
+		AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+			GUID:                guid,
+			Enabled:             getBoolPtr(d, keyEnabled),
+			ActionType:          &actionType,
+			ActionParameter1:    getStrPtr(d, keyEdgeRuleActionParameter1),
+			ActionParameter2:    getStrPtr(d, keyEdgeRuleActionParameter2),
+			Triggers:            triggers,
+			TriggerMatchingType: &matchingType,
+			Description:         getStrPtr(d, keyEdgeRuleDescription),
+		},
+		ExtraActions: extraActions,
 	}, nil
 }
 
 func resourceEdgeRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	pm := meta.(*providerMeta)
 
 	edgeRuleGUID := d.Id()
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	err := clt.PullZone.DeleteEdgeRule(ctx, pullZoneID, edgeRuleGUID)
-	if err != nil {
-		return diagsErrFromErr("deleting edge rule failed", err)
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	diags := edgeRulesDelete(ctx, pm, pullZoneID, []string{edgeRuleGUID})
+	if diags.HasError() {
+		return diags
 	}
 
 	d.SetId("")
-	return nil
+	return diags
 }
 
 func resourceEdgeRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).api
 
 	edgeRuleGUID := d.Id()
 	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
 
-	pz, err := clt.PullZone.Get(ctx, pullZoneID)
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, pullZoneID)
 	if err != nil {
 		return diagsErrFromErr("retrieving pull zone failed", err)
 	}
 
-	if len(pz.EdgeRules) == 0 {
+	if len(edgeRules) == 0 {
 		return diag.Diagnostics{{
 			Severity: diag.Error,
 			Summary:  "pull zone has no edge rules",
 		}}
 	}
 
-	for _, er := range pz.EdgeRules {
+	for _, er := range edgeRules {
 		if er.GUID != nil && *er.GUID == edgeRuleGUID {
 			if err := edgeRuleToResource(er, d); err != nil {
 				return diagsErrFromErr("converting edge rule api type to terraform ResourceData failed", err)
@@ -382,14 +524,14 @@ func resourceEdgeRuleRead(ctx context.Context, d *schema.ResourceData, meta inte
 	}}
 }
 
-func edgeRuleToResource(edgeRule *bunny.EdgeRule, d *schema.ResourceData) error {
+func edgeRuleToResource(edgeRule *edgeRule, d *schema.ResourceData) error {
 	if edgeRule.GUID == nil || *edgeRule.GUID == "" {
 		return errors.New("guid is empty")
 	}
 
 	d.SetId(*edgeRule.GUID)
 
-	actionType, err := intStrMapGet(edgeRuleActionTypesInt, edgeRule.ActionType)
+	actionType, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, edgeRule.ActionType)
 	if err != nil {
 		return fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
 	}
@@ -406,12 +548,24 @@ func edgeRuleToResource(edgeRule *bunny.EdgeRule, d *schema.ResourceData) error
 		return err
 	}
 
+	err = edgeRuleActionBlocksToResource(
+		actionType, ptr.GetString(edgeRule.ActionParameter1), ptr.GetString(edgeRule.ActionParameter2), d,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = edgeRuleExtraActionsToResource(edgeRule.ExtraActions, d)
+	if err != nil {
+		return err
+	}
+
 	err = edgeRuleTriggerToResource(edgeRule.Triggers, d)
 	if err != nil {
 		return fmt.Errorf("converting triggers to resource data failed: %w", err)
 	}
 
-	matchingType, err := intStrMapGet(edgeRuleMatchingTypesInt, edgeRule.TriggerMatchingType)
+	matchingType, err := intStrMapGetOrRaw(edgeRuleMatchingTypesInt, edgeRule.TriggerMatchingType)
 	if err != nil {
 		return fmt.Errorf("%s: %w", keyEdgeRuleTriggerMatchingType, err)
 	}
@@ -432,17 +586,28 @@ func edgeRuleToResource(edgeRule *bunny.EdgeRule, d *schema.ResourceData) error
 }
 
 func edgeRuleTriggerToResource(triggers []*bunny.EdgeRuleTrigger, d *schema.ResourceData) error {
+	res, err := edgeRuleTriggersFlatten(triggers)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(keyEdgeRuleTriggers, res)
+}
+
+// edgeRuleTriggersFlatten converts triggers to the values of trigger
+// blocks.
+func edgeRuleTriggersFlatten(triggers []*bunny.EdgeRuleTrigger) ([]map[string]interface{}, error) {
 	res := make([]map[string]interface{}, 0, len(triggers))
 
 	for _, trigger := range triggers {
-		triggerType, err := intStrMapGet(edgeRuleTriggerTypesInt, trigger.Type)
+		triggerType, err := intStrMapGetOrRaw(edgeRuleTriggerTypesInt, trigger.Type)
 		if err != nil {
-			return fmt.Errorf("%s: %w", triggerType, err)
+			return nil, fmt.Errorf("%s: %w", keyEdgeRuleTriggerType, err)
 		}
 
-		patternMatchingType, err := intStrMapGet(edgeRuleMatchingTypesInt, trigger.PatternMatchingType)
+		patternMatchingType, err := intStrMapGetOrRaw(edgeRuleMatchingTypesInt, trigger.PatternMatchingType)
 		if err != nil {
-			return fmt.Errorf("%s: %w", triggerType, err)
+			return nil, fmt.Errorf("%s: %w", keyEdgeRuleTriggerPatternMatchingType, err)
 		}
 
 		entry := make(map[string]interface{}, 4)
@@ -454,5 +619,5 @@ func edgeRuleTriggerToResource(triggers []*bunny.EdgeRuleTrigger, d *schema.Reso
 		res = append(res, entry)
 	}
 
-	return d.Set(keyEdgeRuleTriggers, res)
+	return res, nil
 }
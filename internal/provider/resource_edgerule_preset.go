@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyEdgeRulePresetCacheTime             = "cache_time"
+	keyEdgeRulePresetContentSecurityPolicy = "content_security_policy"
+	keyEdgeRulePresetDomain                = "domain"
+	keyEdgeRulePresetEdgeRuleGUIDs         = "edge_rule_guids"
+	keyEdgeRulePresetHSTSMaxAge            = "hsts_max_age"
+	keyEdgeRulePresetInSync                = "in_sync"
+	keyEdgeRulePresetIndexURL              = "index_url"
+	keyEdgeRulePresetPreset                = "preset"
+	keyEdgeRulePresetStaticExtensions      = "static_extensions"
+	keyEdgeRulePresetURLPatterns           = "url_patterns"
+)
+
+// edgeRulePresetParamKeys are the keys of all preset parameters.
+var edgeRulePresetParamKeys = []string{
+	keyEdgeRulePresetCacheTime,
+	keyEdgeRulePresetContentSecurityPolicy,
+	keyEdgeRulePresetDomain,
+	keyEdgeRulePresetHSTSMaxAge,
+	keyEdgeRulePresetIndexURL,
+	keyEdgeRulePresetStaticExtensions,
+	keyEdgeRulePresetURLPatterns,
+}
+
+var domainRegexp = regexp.MustCompile(`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+$`)
+
+func resourceEdgeRulePreset() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEdgeRulePresetCreate,
+		ReadContext:   resourceEdgeRulePresetRead,
+		UpdateContext: resourceEdgeRulePresetUpdate,
+		DeleteContext: resourceEdgeRulePresetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEdgeRulePresetImport,
+		},
+
+		CustomizeDiff: customizeDiffAll(
+			edgeRulePresetValidateParams,
+			edgeRulePresetDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			keyEdgeRulePullZoneID: {
+				Type:        schema.TypeInt,
+				Description: "The ID of the Pull Zone to that the Edge Rules belong. The resource must not be combined with a bunny_edgerules resource for the same Pull Zone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			keyEdgeRulePresetPreset: {
+				Type: schema.TypeString,
+				Description: "The preset that is expanded into Edge Rules.\nValid values: " +
+					edgeRulePresetDescription(),
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice(edgeRulePresetKeys, false),
+				),
+			},
+			keyEdgeRulePresetCacheTime: {
+				Type:             schema.TypeInt,
+				Description:      "The cache time in seconds for the preset cache_hashed_assets, if unset 1 year.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			keyEdgeRulePresetContentSecurityPolicy: {
+				Type:             schema.TypeString,
+				Description:      "The value of the Content-Security-Policy header for the preset security_headers, if unset the header is not set.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			keyEdgeRulePresetDomain: {
+				Type:        schema.TypeString,
+				Description: "The apex domain, e.g. example.com, that requests for its www subdomain are redirected to. Required for the preset www_redirect.",
+				Optional:    true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringMatch(domainRegexp, "must be a domain name without scheme and path, e.g. example.com"),
+				),
+			},
+			keyEdgeRulePresetHSTSMaxAge: {
+				Type:             schema.TypeInt,
+				Description:      "The max-age of the Strict-Transport-Security header in seconds for the preset security_headers, if unset 1 year.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			keyEdgeRulePresetIndexURL: {
+				Type: schema.TypeString,
+				Description: "The origin URL of the index document, e.g. https://origin.example.com/index.html, that serves application routes. " +
+					"The request path is passed as path query parameter, e.g. a request for /app/route reaches the origin as https://origin.example.com/index.html?path=/app/route. " +
+					"Required for the preset spa_fallback.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+			},
+			keyEdgeRulePresetStaticExtensions: {
+				Type: schema.TypeSet,
+				Description: "File extensions of static files for the preset spa_fallback, requests for them are not served from index_url. If unset: " +
+					strings.Join(edgeRulePresetDefaultStaticExtensions, ", ") + ".",
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9]+$`), "must be a file extension without dot")),
+				},
+			},
+			keyEdgeRulePresetURLPatterns: {
+				Type: schema.TypeSet,
+				Description: "URL patterns of assets with hashed file names for the preset cache_hashed_assets. If unset: " +
+					strings.Join(edgeRulePresetDefaultURLPatterns, ", ") + ".",
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: func(v interface{}, _ cty.Path) diag.Diagnostics {
+						return diag.FromErr(validateEdgeRuleURLPattern(v.(string)))
+					},
+				},
+			},
+			keyEdgeRulePresetEdgeRuleGUIDs: {
+				Type:        schema.TypeList,
+				Description: "The GUIDs of the Edge Rules that the preset was expanded to.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			keyEdgeRulePresetInSync: {
+				Type:        schema.TypeBool,
+				Description: "Is false if Edge Rules of the preset were changed or deleted outside of Terraform. They are restored on the next apply.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// edgeRulePresetValidateParams fails if parameters are set that the preset
+// does not support or if required parameters of the preset are missing.
+func edgeRulePresetValidateParams(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(keyEdgeRulePresetPreset) {
+		return nil
+	}
+
+	preset := d.Get(keyEdgeRulePresetPreset).(string)
+	supported := edgeRulePresetParams[preset]
+
+	var errs attrErrors
+
+	for _, key := range edgeRulePresetParamKeys {
+		isSet, err := edgeRulePresetParamIsSet(d, key)
+		if err != nil {
+			return err
+		}
+
+		if isSet && !strSliceContains(supported, key) {
+			errs.add(cty.GetAttrPath(key), "%s is not supported by the preset %s, it is only supported by: %s",
+				key, preset, strings.Join(edgeRulePresetsOfParam(key), ", "))
+		}
+	}
+
+	for _, key := range edgeRulePresetRequiredParams[preset] {
+		isSet, err := edgeRulePresetParamIsSet(d, key)
+		if err != nil {
+			return err
+		}
+
+		if !isSet {
+			errs.add(cty.GetAttrPath(key), "%s is required by the preset %s", key, preset)
+		}
+	}
+
+	return errs.err()
+}
+
+// edgeRulePresetParamIsSet returns true if the parameter is configured or its
+// value is unknown.
+func edgeRulePresetParamIsSet(d *schema.ResourceDiff, key string) (bool, error) {
+	if !d.NewValueKnown(key) {
+		return true, nil
+	}
+
+	switch v := d.Get(key).(type) {
+	case string:
+		return v != "", nil
+	case int:
+		return v != 0, nil
+	case *schema.Set:
+		return v.Len() > 0, nil
+	}
+
+	return false, fmt.Errorf("%s: unsupported type %T", key, d.Get(key))
+}
+
+// edgeRulePresetDiff plans the restoration of Edge Rules that were changed
+// outside of Terraform and marks the GUIDs as unknown, when Edge Rules are
+// restored or the preset or its parameters change.
+func edgeRulePresetDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// Edge Rules that were deleted are created again with new GUIDs
+	if !d.Get(keyEdgeRulePresetInSync).(bool) {
+		if err := d.SetNew(keyEdgeRulePresetInSync, true); err != nil {
+			return err
+		}
+
+		return d.SetNewComputed(keyEdgeRulePresetEdgeRuleGUIDs)
+	}
+
+	if d.HasChanges(append([]string{keyEdgeRulePresetPreset}, edgeRulePresetParamKeys...)...) {
+		return d.SetNewComputed(keyEdgeRulePresetEdgeRuleGUIDs)
+	}
+
+	return nil
+}
+
+func resourceEdgeRulePresetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	wanted, err := edgeRulePresetRules(d.Get(keyEdgeRulePresetPreset).(string), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	guids := make([]string, 0, len(wanted))
+
+	var diags diag.Diagnostics
+
+	for _, opts := range wanted {
+		guid, addDiags := edgeRulePresetAdd(ctx, pm, pullZoneID, opts)
+		diags = append(diags, addDiags...)
+
+		if guid != "" {
+			guids = append(guids, guid)
+
+			if d.Id() == "" {
+				d.SetId(uuid.New().String())
+			}
+
+			if err := d.Set(keyEdgeRulePresetEdgeRuleGUIDs, guids); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if diags.HasError() {
+			if len(guids) == 0 {
+				return diags
+			}
+
+			return createFailed(ctx, d, meta, "edge rule preset", diags,
+				func(ctx context.Context) error {
+					return errFromDiags(edgeRulesDelete(ctx, pm, pullZoneID, guids))
+				},
+			)
+		}
+	}
+
+	return append(diags, resourceEdgeRulePresetRead(ctx, d, meta)...)
+}
+
+// edgeRulePresetAdd creates the Edge Rule and returns its GUID. The Pull Zone
+// must be locked via edgeRuleLocks.
+func edgeRulePresetAdd(ctx context.Context, pm *providerMeta, pullZoneID int64, opts *addOrUpdateEdgeRuleOptions) (string, diag.Diagnostics) {
+	before, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return "", diagsErrFromErr("retrieving pull zone failed", err)
+	}
+
+	guid, _, diags := edgeRuleAddVerified(ctx, pm, pullZoneID, before, opts)
+
+	return guid, diags
+}
+
+// resourceEdgeRulePresetUpdate changes the Edge Rules of the preset to the
+// wanted ones. Edge Rules are updated in place, if they differ. Missing Edge
+// Rules are created and Edge Rules that are not needed anymore are deleted.
+func resourceEdgeRulePresetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	wanted, err := edgeRulePresetRules(d.Get(keyEdgeRulePresetPreset).(string), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	o, _ := d.GetChange(keyEdgeRulePresetEdgeRuleGUIDs)
+	current := interfaceSlicetoStrSlice(o.([]interface{}))
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	guids := make([]string, 0, len(wanted))
+
+	var diags diag.Diagnostics
+
+	for i, opts := range wanted {
+		// GUIDs of the preset's Edge Rules that have not been processed yet
+		var remaining []string
+		if i+1 < len(current) {
+			remaining = current[i+1:]
+		}
+
+		edgeRules, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+		if err != nil {
+			return append(diags, diagsErrFromErr("retrieving pull zone failed", err)...)
+		}
+
+		var er *edgeRule
+		if i < len(current) {
+			er = edgeRuleByGUID(edgeRules, current[i])
+		}
+
+		if er == nil {
+			guid, addDiags := edgeRulePresetAdd(ctx, pm, pullZoneID, opts)
+			if guid != "" {
+				guids = append(guids, guid)
+			}
+
+			diags = append(diags, addDiags...)
+			if diags.HasError() {
+				return edgeRulePresetUpdateFailed(d, append(guids, remaining...), diags)
+			}
+
+			continue
+		}
+
+		guids = append(guids, current[i])
+
+		if edgeRulesEqual(er, edgeRuleFromOptions(opts)) {
+			continue
+		}
+
+		withGUID := *opts
+		withGUID.GUID = er.GUID
+
+		_, updateDiags := edgeRuleUpdateVerified(ctx, pm, pullZoneID, edgeRules, &withGUID)
+		diags = append(diags, updateDiags...)
+		if diags.HasError() {
+			return edgeRulePresetUpdateFailed(d, append(guids, remaining...), diags)
+		}
+	}
+
+	if len(current) > len(wanted) {
+		diags = append(diags, edgeRulesDelete(ctx, pm, pullZoneID, current[len(wanted):])...)
+		if diags.HasError() {
+			return edgeRulePresetUpdateFailed(d, append(guids, current[len(wanted):]...), diags)
+		}
+	}
+
+	if err := d.Set(keyEdgeRulePresetEdgeRuleGUIDs, guids); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, resourceEdgeRulePresetRead(ctx, d, meta)...)
+}
+
+// edgeRulePresetUpdateFailed stores the GUIDs of all Edge Rules that might
+// belong to the preset and returns diags.
+func edgeRulePresetUpdateFailed(d *schema.ResourceData, guids []string, diags diag.Diagnostics) diag.Diagnostics {
+	if err := d.Set(keyEdgeRulePresetEdgeRuleGUIDs, guids); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set(keyEdgeRulePresetInSync, false); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// resourceEdgeRulePresetRead compares the Edge Rules of the preset with the
+// ones that the preset expands to. If they differ, in_sync is set to false.
+func resourceEdgeRulePresetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	wanted, err := edgeRulePresetRules(d.Get(keyEdgeRulePresetPreset).(string), d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving pull zone failed", err)
+	}
+
+	guids := interfaceSlicetoStrSlice(d.Get(keyEdgeRulePresetEdgeRuleGUIDs).([]interface{}))
+
+	if err := d.Set(keyEdgeRulePresetInSync, edgeRulePresetInSync(edgeRules, guids, wanted)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// edgeRulePresetInSync returns true if edgeRules contain an Edge Rule per
+// GUID that equals the wanted Edge Rule at the same position.
+func edgeRulePresetInSync(edgeRules []*edgeRule, guids []string, wanted []*addOrUpdateEdgeRuleOptions) bool {
+	if len(guids) != len(wanted) {
+		return false
+	}
+
+	for i, guid := range guids {
+		er := edgeRuleByGUID(edgeRules, guid)
+		if er == nil || !edgeRulesEqual(er, edgeRuleFromOptions(wanted[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resourceEdgeRulePresetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+	guids := interfaceSlicetoStrSlice(d.Get(keyEdgeRulePresetEdgeRuleGUIDs).([]interface{}))
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	diags := edgeRulesDelete(ctx, pm, pullZoneID, guids)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceEdgeRulePresetImport imports the Edge Rules of a preset, the id
+// must be in the format "pullZoneID|preset". The Edge Rules are identified
+// by the preset in their description. The preset parameters are not
+// imported, Edge Rules that differ from the configured parameters are
+// updated on the next apply.
+func resourceEdgeRulePresetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idAttr := strings.SplitN(d.Id(), "|", 2)
+	if len(idAttr) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"pullZoneID|preset\"", d.Id())
+	}
+
+	pullZoneID, err := strconv.ParseInt(idAttr[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, pullZoneID should be an integer", idAttr[0])
+	}
+
+	preset := idAttr[1]
+	if !strSliceContains(edgeRulePresetKeys, preset) {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, preset must be one of: %s", d.Id(), strings.Join(edgeRulePresetKeys, ", "))
+	}
+
+	edgeRules, err := meta.(*providerMeta).api.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving pull zone failed: %w", err)
+	}
+
+	prefix := edgeRulePresetDescriptionPrefix(preset)
+
+	var guids []string
+	for _, er := range edgeRules {
+		if strings.HasPrefix(ptr.GetString(er.Description), prefix) {
+			guids = append(guids, ptr.GetString(er.GUID))
+		}
+	}
+
+	if len(guids) == 0 {
+		return nil, fmt.Errorf("pull zone %d has no edge rules of the preset %q", pullZoneID, preset)
+	}
+
+	if err := d.Set(keyEdgeRulePullZoneID, pullZoneID); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(keyEdgeRulePresetPreset, preset); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set(keyEdgeRulePresetEdgeRuleGUIDs, guids); err != nil {
+		return nil, err
+	}
+
+	d.SetId(uuid.New().String())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func strSliceContains(strs []string, s string) bool {
+	for _, elem := range strs {
+		if elem == s {
+			return true
+		}
+	}
+
+	return false
+}
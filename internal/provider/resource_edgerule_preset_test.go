@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccEdgeRulePreset_basic(t *testing.T) {
+	const resourceName = "bunny_edgerule_preset.headers"
+	var pullZoneID int64
+	var guid string
+	pzName := randResourceName()
+
+	tf := func(preset string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerule_preset" "headers" {
+	pull_zone_id = bunny_pullzone.mypz.id
+%s
+}`, pzName, preset)
+	}
+
+	withCSP := tf(`
+	preset = "security_headers"
+	content_security_policy = "default-src 'self'"
+`)
+
+	withoutCSP := tf(`
+	preset = "security_headers"
+	hsts_max_age = 600
+`)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: withCSP,
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"preset security_headers: Strict-Transport-Security header",
+						"preset security_headers: X-Content-Type-Options header",
+						"preset security_headers: Content-Security-Policy header",
+					),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_guids.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "in_sync", "true"),
+					func(s *terraform.State) error {
+						strID, err := idFromState(s, "bunny_pullzone.mypz")
+						if err != nil {
+							return err
+						}
+
+						pullZoneID, err = strconv.ParseInt(strID, 10, 64)
+						if err != nil {
+							return err
+						}
+
+						guid = s.RootModule().Resources[resourceName].Primary.Attributes["edge_rule_guids.1"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: withoutCSP,
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"preset security_headers: Strict-Transport-Security header",
+						"preset security_headers: X-Content-Type-Options header",
+					),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_guids.#", "2"),
+				),
+			},
+			{
+				// an edge rule of the preset that was deleted outside of
+				// terraform is reported as drift
+				PreConfig: func() {
+					if err := newAPIClient().PullZone.DeleteEdgeRule(context.Background(), pullZoneID, guid); err != nil {
+						t.Fatalf("deleting edge rule failed: %s", err)
+					}
+				},
+				Config:             withoutCSP,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: withoutCSP,
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"preset security_headers: Strict-Transport-Security header",
+						"preset security_headers: X-Content-Type-Options header",
+					),
+					resource.TestCheckResourceAttr(resourceName, "in_sync", "true"),
+				),
+			},
+			{
+				Config: tf(`
+	preset = "www_redirect"
+	domain = "example.com"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"preset www_redirect: redirect www.example.com to example.com",
+					),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_guids.#", "1"),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%d|www_redirect", pullZoneID), nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+
+					if got := states[0].Attributes["edge_rule_guids.#"]; got != "1" {
+						return fmt.Errorf("expected 1 imported edge rule guid, got %s", got)
+					}
+
+					return nil
+				},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccEdgeRulePreset_paramValidation(t *testing.T) {
+	tf := func(preset string) string {
+		return fmt.Sprintf(`
+resource "bunny_edgerule_preset" "p" {
+	pull_zone_id = 1
+%s
+}`, preset)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	preset = "www_redirect"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`domain is required by the preset www_redirect`),
+			},
+			{
+				Config: tf(`
+	preset = "block_dotfiles"
+	cache_time = 60
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`cache_time is not supported by the preset block_dotfiles`),
+			},
+		},
+	})
+}
+
+func TestEdgeRulePresetValidateParamsAttributePath(t *testing.T) {
+	r := resourceEdgeRulePreset()
+	cfg := testObjectVal(r, r.CoreConfigSchema().ImpliedType(), map[string]cty.Value{
+		keyEdgeRulePullZoneID:   cty.NumberIntVal(1),
+		keyEdgeRulePresetPreset: cty.StringVal(edgeRulePresetWWWRedirect),
+	})
+
+	path := testDiagAttributePath(t, testPlanCreate(t, "bunny_edgerule_preset", cfg))
+
+	want := tftypes.NewAttributePath().WithAttributeName(keyEdgeRulePresetDomain)
+	if !path.Equal(want) {
+		t.Errorf("expected the diagnostic for attribute %s, got: %s", want, path)
+	}
+}
+
+func TestEdgeRulePresetImport(t *testing.T) {
+	fake := &fakeEdgeRuleAPI{edgeRules: []*edgeRule{
+		testEdgeRule("a", "preset security_headers: Strict-Transport-Security header"),
+		testEdgeRule("b", "other"),
+		testEdgeRule("c", "preset security_headers: X-Content-Type-Options header"),
+		testEdgeRule("d", "preset www_redirect: redirect www.example.com to example.com"),
+	}}
+	pm := fake.providerMeta(t, 0)
+
+	testCases := []struct {
+		id        string
+		wantGUIDs []string
+		wantErr   string
+	}{
+		{id: "1|security_headers", wantGUIDs: []string{"a", "c"}},
+		{id: "1|www_redirect", wantGUIDs: []string{"d"}},
+		{id: "1|spa_fallback", wantErr: "has no edge rules of the preset"},
+		{id: "1|unknown", wantErr: "preset must be one of"},
+		{id: "x|security_headers", wantErr: "pullZoneID should be an integer"},
+		{id: "1", wantErr: "should be in format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			d := resourceEdgeRulePreset().TestResourceData()
+			d.SetId(tc.id)
+
+			res, err := resourceEdgeRulePresetImport(context.Background(), d, pm)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(res) != 1 {
+				t.Fatalf("expected 1 resource, got %d", len(res))
+			}
+
+			if got := res[0].Get(keyEdgeRulePullZoneID).(int); got != 1 {
+				t.Errorf("expected pull zone id 1, got %d", got)
+			}
+
+			if got := interfaceSlicetoStrSlice(res[0].Get(keyEdgeRulePresetEdgeRuleGUIDs).([]interface{})); !reflect.DeepEqual(got, tc.wantGUIDs) {
+				t.Errorf("expected edge rule guids %v, got %v", tc.wantGUIDs, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -85,7 +87,7 @@ func sortEdgeRules(t *testing.T, rules []*bunny.EdgeRule) {
 
 var edgeRuleDiffIgnoredFields = map[string]struct{}{
 	"GUID":        {}, // is set as ID in resourceData, GUID does not exist in resourceData
-	"Description": {}, // computed field, used internally by our provider for initial identification
+	"Description": {}, // optional and computed, compared separately in the tests that configure it
 }
 
 func edgeRuleDiff(t *testing.T, a, b interface{}) []string {
@@ -234,6 +236,7 @@ resource "bunny_edgerule" "er3" {
 	trigger_matching_type = "any"
 	trigger {
 		type = "query_string"
+		parameter_1 = "action"
 		pattern_matching_type = "any"
 		pattern_matches = ["set_hostname"]
 	}
@@ -252,6 +255,7 @@ resource "bunny_edgerule" "er3" {
 							Triggers: []*bunny.EdgeRuleTrigger{
 								{
 									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURLQueryString),
+									Parameter1:          ptr.ToString("action"),
 									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
 									PatternMatches:      []string{"set_hostname"},
 								},
@@ -622,3 +626,446 @@ resource "bunny_edgerule" "er1" {
 		},
 	})
 }
+
+func TestAccEdgeRule_typedActionBlocks(t *testing.T) {
+	const resourceName = "bunny_edgerule.myer"
+	pzName := randResourceName()
+
+	tf := func(action string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerule" "myer" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		type = "url"
+		pattern_matches = ["*/old/*"]
+	}
+
+	%s
+}`, pzName, action)
+	}
+
+	checkAction := func(actionType int, param1, param2 string) resource.TestCheckFunc {
+		return checkEdgeRulesState(t, &edgeRulesWanted{
+			TerraformPullZoneResourceName: "bunny_pullzone.mypz",
+			PullZoneName:                  pzName,
+			EdgeRules: []*bunny.EdgeRule{
+				{
+					Enabled:             ptr.ToBool(true),
+					ActionType:          ptr.ToInt(actionType),
+					TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+					ActionParameter1:    ptr.ToString(param1),
+					ActionParameter2:    ptr.ToString(param2),
+					Triggers: []*bunny.EdgeRuleTrigger{
+						{
+							PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+							Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+							PatternMatches:      []string{"*/old/*"},
+							Parameter1:          ptr.ToString(""),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	redirect {
+		url = "https://bunny.net/new"
+		status_code = 308
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					checkAction(bunny.EdgeRuleActionTypeRedirect, "https://bunny.net/new", "308"),
+					resource.TestCheckResourceAttr(resourceName, "action_type", "redirect"),
+					resource.TestCheckResourceAttr(resourceName, "action_parameter_1", "https://bunny.net/new"),
+					resource.TestCheckResourceAttr(resourceName, "action_parameter_2", "308"),
+				),
+			},
+			{
+				Config: tf(`
+	set_response_header {
+		name = "X-Frame-Options"
+		value = "DENY"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					checkAction(bunny.EdgeRuleActionTypeSetResponseHeader, "X-Frame-Options", "DENY"),
+					resource.TestCheckResourceAttr(resourceName, "redirect.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "action_type", "set_response_header"),
+				),
+			},
+			{
+				// the same action via the raw parameters causes no changes
+				Config: tf(`
+	action_type = "set_response_header"
+	action_parameter_1 = "X-Frame-Options"
+	action_parameter_2 = "DENY"`),
+				PlanOnly: true,
+			},
+			{
+				Config: tf(`
+	override_cache_time {
+		seconds = 3600
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					checkAction(bunny.EdgeRuleActionTypeOverrideCacheTime, "3600", ""),
+					resource.TestCheckResourceAttr(resourceName, "set_response_header.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					pzID, err := idFromState(s, "bunny_pullzone.mypz")
+					if err != nil {
+						return "", fmt.Errorf("could not get pull zone id from state: %w", err)
+					}
+					edgeruleID, err := idFromState(s, resourceName)
+					if err != nil {
+						return "", fmt.Errorf("could not get edgerule id from state: %w", err)
+					}
+
+					return fmt.Sprintf("%s/%s", pzID, edgeruleID), nil
+				},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccEdgeRule_typedActionBlockValidation(t *testing.T) {
+	tf := func(action string) string {
+		return fmt.Sprintf(`
+resource "bunny_edgerule" "myer" {
+	pull_zone_id = 1
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		type = "url"
+		pattern_matches = ["*"]
+	}
+
+	%s
+}`, action)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	redirect {
+		url = "https://bunny.net"
+		status_code = 200
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected redirect.0.status_code to be one of`),
+			},
+			{
+				Config: tf(`
+	redirect {
+		url = "bunny.net"
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be an http:// or https:// URL`),
+			},
+			{
+				Config: tf(`
+	set_request_header {
+		name = "Invalid Header"
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be a valid HTTP header name`),
+			},
+			{
+				Config: tf(`
+	action_type = "block_request"
+	redirect {
+		url = "https://bunny.net"
+	}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+		},
+	})
+}
+
+func TestAccEdgeRule_triggerValidation(t *testing.T) {
+	tf := func(trigger string) string {
+		return fmt.Sprintf(`
+resource "bunny_edgerule" "myer" {
+	pull_zone_id = 1
+	action_type = "block_request"
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		%s
+	}
+}`, trigger)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+		type = "country_code"
+		pattern_matches = ["DE", "XX"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"XX" of country_code trigger must be an ISO 3166-1 alpha-2 country code`),
+			},
+			{
+				Config: tf(`
+		type = "remote_ip"
+		pattern_matches = ["10.0.0.0/33"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be an IP address or a CIDR network`),
+			},
+			{
+				Config: tf(`
+		type = "random_chance"
+		pattern_matches = ["101"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be a percentage between 0 and 100`),
+			},
+			{
+				Config: tf(`
+		type = "url"
+		pattern_matches = ["blog/*"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must start with`),
+			},
+			{
+				Config: tf(`
+		type = "request_header"
+		pattern_matches = ["*"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`parameter_1 is required for request_header triggers`),
+			},
+		},
+	})
+}
+
+// checkEdgeRuleExtraActions retrieves the Edge Rule of the resource via the
+// API and compares its additional actions with wanted.
+func checkEdgeRuleExtraActions(resourceName string, wanted []*edgeRuleAction) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[resourceName]
+		if rs == nil {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		pullZoneID, err := strconv.ParseInt(rs.Primary.Attributes[keyEdgeRulePullZoneID], 10, 64)
+		if err != nil {
+			return fmt.Errorf("could not parse pull zone id of %s: %w", resourceName, err)
+		}
+
+		clt := newBunnyAPIClient(os.Getenv(envVarAPIKey), userAgent+"-test")
+		edgeRules, err := clt.pullZoneEdgeRules(context.Background(), pullZoneID)
+		if err != nil {
+			return fmt.Errorf("fetching edge rules of pull zone %d failed: %w", pullZoneID, err)
+		}
+
+		for _, er := range edgeRules {
+			if ptr.GetString(er.GUID) != rs.Primary.ID {
+				continue
+			}
+
+			if len(er.ExtraActions) != len(wanted) {
+				return fmt.Errorf("edge rule has %d extra actions, expected %d", len(er.ExtraActions), len(wanted))
+			}
+
+			for i, action := range er.ExtraActions {
+				if ptr.GetInt(action.ActionType) != ptr.GetInt(wanted[i].ActionType) ||
+					ptr.GetString(action.ActionParameter1) != ptr.GetString(wanted[i].ActionParameter1) ||
+					ptr.GetString(action.ActionParameter2) != ptr.GetString(wanted[i].ActionParameter2) {
+					return fmt.Errorf("extra action %d is %+v, expected %+v", i, action, wanted[i])
+				}
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("pull zone %d has no edge rule with guid %q", pullZoneID, rs.Primary.ID)
+	}
+}
+
+func TestAccEdgeRule_extraActions(t *testing.T) {
+	const resourceName = "bunny_edgerule.myer"
+	pzName := randResourceName()
+
+	tf := func(actions string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerule" "myer" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		type = "url"
+		pattern_matches = ["*/assets/*"]
+	}
+
+	set_response_header {
+		name = "X-Frame-Options"
+		value = "DENY"
+	}
+
+	%s
+}`, pzName, actions)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	action {
+		type = "set_response_header"
+		parameter_1 = "X-Content-Type-Options"
+		parameter_2 = "nosniff"
+	}
+
+	action {
+		type = "override_cache_time"
+		parameter_1 = "86400"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "action_type", "set_response_header"),
+					resource.TestCheckResourceAttr(resourceName, "action.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "action.0.type", "set_response_header"),
+					resource.TestCheckResourceAttr(resourceName, "action.1.type", "override_cache_time"),
+					checkEdgeRuleExtraActions(resourceName, []*edgeRuleAction{
+						{
+							ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeSetResponseHeader),
+							ActionParameter1: ptr.ToString("X-Content-Type-Options"),
+							ActionParameter2: ptr.ToString("nosniff"),
+						},
+						{
+							ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeOverrideCacheTime),
+							ActionParameter1: ptr.ToString("86400"),
+						},
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					pzID, err := idFromState(s, "bunny_pullzone.mypz")
+					if err != nil {
+						return "", fmt.Errorf("could not get pull zone id from state: %w", err)
+					}
+					edgeruleID, err := idFromState(s, resourceName)
+					if err != nil {
+						return "", fmt.Errorf("could not get edgerule id from state: %w", err)
+					}
+
+					return fmt.Sprintf("%s/%s", pzID, edgeruleID), nil
+				},
+			},
+			{
+				Config: tf(`
+	action {
+		type = "override_cache_time"
+		parameter_1 = "3600"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "action.#", "1"),
+					checkEdgeRuleExtraActions(resourceName, []*edgeRuleAction{
+						{
+							ActionType:       ptr.ToInt(bunny.EdgeRuleActionTypeOverrideCacheTime),
+							ActionParameter1: ptr.ToString("3600"),
+						},
+					}),
+				),
+			},
+			{
+				Config: tf(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "action.#", "0"),
+					checkEdgeRuleExtraActions(resourceName, nil),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccEdgeRule_description(t *testing.T) {
+	const resourceName = "bunny_edgerule.myer"
+	pzName := randResourceName()
+
+	tf := func(description string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerule" "myer" {
+	pull_zone_id = bunny_pullzone.mypz.id
+	action_type = "block_request"
+	description = "%s"
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		type = "url"
+		pattern_matches = ["*/admin/*"]
+	}
+}`, pzName, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf("block admin interface"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "block admin interface"),
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "block admin interface"),
+				),
+			},
+			{
+				Config: tf("block admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "block admin"),
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "block admin"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					pzID, err := idFromState(s, "bunny_pullzone.mypz")
+					if err != nil {
+						return "", fmt.Errorf("could not get pull zone id from state: %w", err)
+					}
+					edgeruleID, err := idFromState(s, resourceName)
+					if err != nil {
+						return "", fmt.Errorf("could not get edgerule id from state: %w", err)
+					}
+
+					return fmt.Sprintf("%s/%s", pzID, edgeruleID), nil
+				},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
@@ -0,0 +1,418 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyEdgeRulesEdgeRules = "edge_rule"
+	keyEdgeRulesGUID      = "guid"
+)
+
+var resourceEdgeRulesEdgeRule = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		keyEdgeRulesGUID: {
+			Type:        schema.TypeString,
+			Description: "The GUID of the Edge Rule.",
+			Computed:    true,
+		},
+		keyEdgeRuleActionType: {
+			Type: schema.TypeString,
+			Description: "The action type of the Edge Rule. Types that are not supported by the provider are read as their number.\nValid values: " +
+				strings.Join(edgeRuleActionTypeKeys, ", "),
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(edgeRuleActionTypeKeys, false),
+			),
+		},
+		keyEdgeRuleActionParameter1: {
+			Type:        schema.TypeString,
+			Description: "The Action parameter 1. The value depends on the action type: " + typeParameterDocs(edgeRuleActionParameter1Docs) + ".",
+			Optional:    true,
+		},
+		keyEdgeRuleActionParameter2: {
+			Type:        schema.TypeString,
+			Description: "The Action parameter 2. The value depends on the action type: " + typeParameterDocs(edgeRuleActionParameter2Docs) + ".",
+			Optional:    true,
+		},
+		keyEdgeRuleExtraActions: {
+			Type:        schema.TypeList,
+			Description: "Additional actions that are executed in the given order after the action defined by action_type.",
+			Optional:    true,
+			Elem:        resourceEdgeRuleExtraAction,
+		},
+		keyEdgeRuleTriggers: {
+			Type:     schema.TypeSet,
+			Required: true,
+			MaxItems: 5, // otherwise the API returns the error: Maximum 5 condition are allowed per rule.
+			Elem:     resourceEdgeRuleTrigger,
+		},
+		keyEdgeRuleTriggerMatchingType: {
+			Type:        schema.TypeString,
+			Description: "The trigger matching type.\nValid values: " + strings.Join(edgeRuleMatchingTypeKeys, ", "),
+			Required:    true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice(edgeRuleMatchingTypeKeys, false),
+			),
+		},
+		keyEdgeRuleDescription: {
+			Type:        schema.TypeString,
+			Description: "The description of the Edge Rule.",
+			Optional:    true,
+		},
+		keyEdgeRuleEnabled: {
+			Type:        schema.TypeBool,
+			Description: "Determines if the edge rule is currently enabled or not.",
+			Optional:    true,
+			Default:     true,
+		},
+	},
+}
+
+func resourceEdgeRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEdgeRulesCreate,
+		ReadContext:   resourceEdgeRulesRead,
+		UpdateContext: resourceEdgeRulesUpdate,
+		DeleteContext: resourceEdgeRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: edgeRulesTriggersValidate,
+
+		Schema: map[string]*schema.Schema{
+			keyEdgeRulePullZoneID: {
+				Type:        schema.TypeInt,
+				Description: "The ID of the Pull Zone to that the Edge Rules belong.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			keyEdgeRulesEdgeRules: {
+				Type:        schema.TypeList,
+				Description: "All Edge Rules of the Pull Zone, in the order in which they are evaluated. Edge Rules of the Pull Zone that are not configured are reported as changes and deleted. The resource must not be combined with bunny_edgerule resources for the same Pull Zone.",
+				Optional:    true,
+				Elem:        resourceEdgeRulesEdgeRule,
+			},
+		},
+	}
+}
+
+// edgeRulesTriggersValidate validates the triggers of all configured Edge
+// Rules.
+func edgeRulesTriggersValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(keyEdgeRulesEdgeRules) {
+		return nil
+	}
+
+	var errs attrErrors
+
+	for i := range d.Get(keyEdgeRulesEdgeRules).([]interface{}) {
+		key := fmt.Sprintf("%s.%d.%s", keyEdgeRulesEdgeRules, i, keyEdgeRuleTriggers)
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		path := cty.GetAttrPath(keyEdgeRulesEdgeRules).IndexInt(i).GetAttr(keyEdgeRuleTriggers)
+		edgeRuleTriggersValidateSet(&errs, d.Get(key).(*schema.Set), path)
+	}
+
+	return errs.err()
+}
+
+func resourceEdgeRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(d.Get(keyEdgeRulePullZoneID).(int)))
+
+	return resourceEdgeRulesUpdate(ctx, d, meta)
+}
+
+// resourceEdgeRulesUpdate reconciles the Edge Rules of the Pull Zone with
+// the configured ones. The result is verified by retrieving the Edge Rules
+// afterwards, if they differ the reconciliation is retried.
+func resourceEdgeRulesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	wanted, err := edgeRulesFromResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	current, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving edge rules failed", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := edgeRulesReconcile(ctx, pm, pullZoneID, current, wanted); err != nil {
+			return diag.FromErr(err)
+		}
+
+		current, err = pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+		if err != nil {
+			return diagsErrFromErr("retrieving edge rules for verification failed", err)
+		}
+
+		if edgeRulesMatch(current, wanted) {
+			break
+		}
+
+		if attempt >= pm.edgeRuleWriteRetries {
+			return diag.Errorf("verifying edge rules failed, retry budget (%s) exhausted: the edge rules of the pull zone differ from the configured ones",
+				keyEdgeRuleWriteRetries)
+		}
+
+		logger.Infof("pull zone %d: edge rules differ from the configured ones after reconciling, retrying (%d/%d)",
+			pullZoneID, attempt+1, pm.edgeRuleWriteRetries)
+	}
+
+	return resourceEdgeRulesRead(ctx, d, meta)
+}
+
+// edgeRulesReconcile changes the current Edge Rules of the Pull Zone to the
+// wanted ones in a single pass.
+// The Edge Rules are matched by their position. A rule that exists at the
+// same position is updated in place if it differs. Rules that exceed the
+// number of wanted ones are deleted, missing rules are added. The API
+// appends new rules, this keeps the order of the rules the same as in
+// wanted.
+func edgeRulesReconcile(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	current []*edgeRule,
+	wanted []*addOrUpdateEdgeRuleOptions,
+) error {
+	for i := len(wanted); i < len(current); i++ {
+		guid := ptr.GetString(current[i].GUID)
+
+		logger.Infof("pull zone %d: deleting edge rule %q", pullZoneID, guid)
+
+		if err := pm.client.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid); err != nil {
+			return fmt.Errorf("deleting edge rule %q failed: %w", guid, err)
+		}
+	}
+
+	for i, w := range wanted {
+		opts := *w
+
+		if i < len(current) {
+			if edgeRulesEqual(current[i], edgeRuleFromOptions(&opts)) {
+				continue
+			}
+
+			opts.GUID = current[i].GUID
+
+			if edgeRuleOnlyEnabledDiffers(current[i], &opts) {
+				logger.Infof("pull zone %d: setting enabled of edge rule %q at position %d to %t",
+					pullZoneID, ptr.GetString(opts.GUID), i, ptr.GetBool(opts.Enabled))
+
+				err := pm.client.PullZone.SetEdgeRuleEnabled(ctx, pullZoneID, ptr.GetString(opts.GUID), &bunny.SetEdgeRuleEnabledOptions{
+					ID:    &pullZoneID,
+					Value: opts.Enabled,
+				})
+				if err != nil {
+					return fmt.Errorf("setting enabled of edge rule at position %d failed: %w", i, err)
+				}
+
+				continue
+			}
+
+			logger.Infof("pull zone %d: updating edge rule %q at position %d", pullZoneID, ptr.GetString(opts.GUID), i)
+		} else {
+			logger.Infof("pull zone %d: adding edge rule at position %d", pullZoneID, i)
+		}
+
+		if err := pm.api.edgeRuleAddOrUpdate(ctx, pullZoneID, &opts); err != nil {
+			return fmt.Errorf("applying edge rule at position %d failed: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// edgeRuleOnlyEnabledDiffers returns true if current and the wanted Edge
+// Rule only differ in the enabled setting.
+func edgeRuleOnlyEnabledDiffers(current *edgeRule, wanted *addOrUpdateEdgeRuleOptions) bool {
+	toggled := *current
+	toggled.Enabled = ptr.ToBool(!ptr.GetBool(current.Enabled))
+
+	return edgeRulesEqual(&toggled, edgeRuleFromOptions(wanted))
+}
+
+// edgeRulesMatch returns true if the current Edge Rules have the same
+// settings and order as the wanted ones.
+func edgeRulesMatch(current []*edgeRule, wanted []*addOrUpdateEdgeRuleOptions) bool {
+	if len(current) != len(wanted) {
+		return false
+	}
+
+	for i, w := range wanted {
+		if !edgeRuleApplied(current[i], w) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resourceEdgeRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+
+	pullZoneID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.Errorf("invalid id %q, must be the ID of a pull zone", d.Id())
+	}
+
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving edge rules failed", err)
+	}
+
+	list, err := edgeRulesFlatten(edgeRules)
+	if err != nil {
+		return diagsErrFromErr("converting edge rule api type to terraform ResourceData failed", err)
+	}
+
+	if err := d.Set(keyEdgeRulePullZoneID, pullZoneID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(keyEdgeRulesEdgeRules, list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceEdgeRulesDelete deletes all Edge Rules of the Pull Zone.
+func resourceEdgeRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	clt := pm.api
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving edge rules failed", err)
+	}
+
+	for _, er := range edgeRules {
+		guid := ptr.GetString(er.GUID)
+
+		if err := pm.client.PullZone.DeleteEdgeRule(ctx, pullZoneID, guid); err != nil {
+			return diagsErrFromErr(fmt.Sprintf("deleting edge rule %q failed", guid), err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// edgeRulesFromResource returns the configured Edge Rules in their order.
+func edgeRulesFromResource(d *schema.ResourceData) ([]*addOrUpdateEdgeRuleOptions, error) {
+	list := d.Get(keyEdgeRulesEdgeRules).([]interface{})
+	res := make([]*addOrUpdateEdgeRuleOptions, 0, len(list))
+
+	for i, elem := range list {
+		opts, err := edgeRulesElemToOptions(structure(elem.(map[string]interface{})))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%d: %w", keyEdgeRulesEdgeRules, i, err)
+		}
+
+		res = append(res, opts)
+	}
+
+	return res, nil
+}
+
+func edgeRulesElemToOptions(m structure) (*addOrUpdateEdgeRuleOptions, error) {
+	actionType, err := strIntMapGetOrRaw(edgeRuleActionTypesStr, m.getStr(keyEdgeRuleActionType))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
+	}
+
+	matchingType, err := strIntMapGetOrRaw(edgeRuleMatchingTypesStr, m.getStr(keyEdgeRuleTriggerMatchingType))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyEdgeRuleTriggerMatchingType, err)
+	}
+
+	triggers, err := edgeRuleTriggersFromSet(m[keyEdgeRuleTriggers].(*schema.Set))
+	if err != nil {
+		return nil, fmt.Errorf("converting edge rule triggers failed: %w", err)
+	}
+
+	extraActions, err := edgeRuleExtraActionsFromList(m[keyEdgeRuleExtraActions].([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &addOrUpdateEdgeRuleOptions{
+		AddOrUpdateEdgeRuleOptions: bunny.AddOrUpdateEdgeRuleOptions{
+			Enabled:             m.getBoolPtr(keyEdgeRuleEnabled),
+			ActionType:          &actionType,
+			ActionParameter1:    m.getStrPtr(keyEdgeRuleActionParameter1),
+			ActionParameter2:    m.getStrPtr(keyEdgeRuleActionParameter2),
+			Triggers:            triggers,
+			TriggerMatchingType: &matchingType,
+			Description:         m.getStrPtr(keyEdgeRuleDescription),
+		},
+		ExtraActions: extraActions,
+	}, nil
+}
+
+// edgeRulesFlatten converts the Edge Rules to the values of edge_rule
+// blocks.
+func edgeRulesFlatten(edgeRules []*edgeRule) ([]map[string]interface{}, error) {
+	res := make([]map[string]interface{}, 0, len(edgeRules))
+
+	for _, er := range edgeRules {
+		actionType, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, er.ActionType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyEdgeRuleActionType, err)
+		}
+
+		matchingType, err := intStrMapGetOrRaw(edgeRuleMatchingTypesInt, er.TriggerMatchingType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyEdgeRuleTriggerMatchingType, err)
+		}
+
+		triggers, err := edgeRuleTriggersFlatten(er.Triggers)
+		if err != nil {
+			return nil, fmt.Errorf("converting triggers failed: %w", err)
+		}
+
+		extraActions, err := edgeRuleExtraActionsFlatten(er.ExtraActions)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, map[string]interface{}{
+			keyEdgeRulesGUID:               ptr.GetString(er.GUID),
+			keyEdgeRuleActionType:          actionType,
+			keyEdgeRuleActionParameter1:    ptr.GetString(er.ActionParameter1),
+			keyEdgeRuleActionParameter2:    ptr.GetString(er.ActionParameter2),
+			keyEdgeRuleExtraActions:        extraActions,
+			keyEdgeRuleTriggers:            triggers,
+			keyEdgeRuleTriggerMatchingType: matchingType,
+			keyEdgeRuleDescription:         ptr.GetString(er.Description),
+			keyEdgeRuleEnabled:             ptr.GetBool(er.Enabled),
+		})
+	}
+
+	return res, nil
+}
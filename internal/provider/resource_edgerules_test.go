@@ -0,0 +1,355 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// checkEdgeRulesOrder retrieves the edge rules of the pull zone of the
+// terraform resource pullZoneResourceName and compares their descriptions
+// with wantedDescriptions, including the order.
+func checkEdgeRulesOrder(pullZoneResourceName string, wantedDescriptions ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		strID, err := idFromState(s, pullZoneResourceName)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.ParseInt(strID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("could not convert resource ID %q to int64: %w", strID, err)
+		}
+
+		pz, err := newAPIClient().PullZone.Get(context.Background(), id)
+		if err != nil {
+			return fmt.Errorf("fetching pull-zone with id %d from api client failed: %w", id, err)
+		}
+
+		descriptions := make([]string, 0, len(pz.EdgeRules))
+		for _, er := range pz.EdgeRules {
+			descriptions = append(descriptions, ptr.GetString(er.Description))
+		}
+
+		if !reflect.DeepEqual(descriptions, wantedDescriptions) {
+			return fmt.Errorf("pull zone has the edge rules [%s], expected [%s]",
+				strings.Join(descriptions, ", "), strings.Join(wantedDescriptions, ", "),
+			)
+		}
+
+		return nil
+	}
+}
+
+func TestAccEdgeRules_basic(t *testing.T) {
+	const resourceName = "bunny_edgerules.myers"
+	var pullZoneID int64
+	pzName := randResourceName()
+
+	edgeRule := func(description, header string) string {
+		return fmt.Sprintf(`
+	edge_rule {
+		description = "%s"
+		action_type = "set_response_header"
+		action_parameter_1 = "%s"
+		action_parameter_2 = "1"
+		trigger_matching_type = "all"
+		trigger {
+			pattern_matching_type = "any"
+			type = "url"
+			pattern_matches = ["*"]
+		}
+	}
+`, description, header)
+	}
+
+	tf := func(edgeRules ...string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_edgerules" "myers" {
+	pull_zone_id = bunny_pullzone.mypz.id
+%s
+}`, pzName, strings.Join(edgeRules, ""))
+	}
+
+	threeRules := tf(
+		edgeRule("third", "X-Third"),
+		edgeRule("first", "X-First"),
+		edgeRule("second", "X-Second"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(
+					edgeRule("first", "X-First"),
+					edgeRule("second", "X-Second"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "first", "second"),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "edge_rule.0.guid"),
+					func(s *terraform.State) error {
+						strID, err := idFromState(s, "bunny_pullzone.mypz")
+						if err != nil {
+							return err
+						}
+
+						pullZoneID, err = strconv.ParseInt(strID, 10, 64)
+						return err
+					},
+				),
+			},
+			{
+				Config: threeRules,
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "third", "first", "second"),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule.0.action_parameter_1", "X-Third"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return idFromState(s, "bunny_pullzone.mypz")
+				},
+			},
+			{
+				// an edge rule that was created outside of terraform is
+				// reported as drift
+				PreConfig: func() {
+					err := newAPIClient().PullZone.AddOrUpdateEdgeRule(
+						context.Background(),
+						pullZoneID,
+						&bunny.AddOrUpdateEdgeRuleOptions{
+							ActionType:          ptr.ToInt(bunny.EdgeRuleActionTypeBlockRequest),
+							TriggerMatchingType: ptr.ToInt(bunny.MatchingTypeAll),
+							Description:         ptr.ToString("unmanaged"),
+							Enabled:             ptr.ToBool(true),
+							Triggers: []*bunny.EdgeRuleTrigger{
+								{
+									Type:                ptr.ToInt(bunny.EdgeRuleTriggerTypeURL),
+									PatternMatchingType: ptr.ToInt(bunny.MatchingTypeAny),
+									PatternMatches:      []string{"*/admin/*"},
+								},
+							},
+						},
+					)
+					if err != nil {
+						t.Fatalf("creating unmanaged edge rule failed: %s", err)
+					}
+				},
+				Config:             threeRules,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: threeRules,
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "third", "first", "second"),
+				),
+			},
+			{
+				Config: tf(
+					edgeRule("second", "X-Second"),
+				),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz", "second"),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule.#", "1"),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestEdgeRuleOnlyEnabledDiffers(t *testing.T) {
+	current := testEdgeRule("a", "rule", "/a/*")
+
+	disabled := edgeRuleToOptions(testEdgeRule("a", "rule", "/a/*"))
+	disabled.Enabled = ptr.ToBool(false)
+	if !edgeRuleOnlyEnabledDiffers(current, disabled) {
+		t.Error("edge rules that only differ in enabled are reported as having other differences")
+	}
+
+	if edgeRuleOnlyEnabledDiffers(current, edgeRuleToOptions(testEdgeRule("a", "rule", "/a/*"))) {
+		t.Error("equal edge rules are reported as only differing in enabled")
+	}
+
+	disabledAndChanged := edgeRuleToOptions(testEdgeRule("a", "other rule", "/a/*"))
+	disabledAndChanged.Enabled = ptr.ToBool(false)
+	if edgeRuleOnlyEnabledDiffers(current, disabledAndChanged) {
+		t.Error("edge rules with different enabled and descriptions are reported as only differing in enabled")
+	}
+}
+
+func TestEdgeRulesTriggersValidateAttributePath(t *testing.T) {
+	r := resourceEdgeRules()
+	ty := r.CoreConfigSchema().ImpliedType()
+	edgeRuleTy := ty.AttributeType(keyEdgeRulesEdgeRules).ElementType()
+	triggerTy := edgeRuleTy.AttributeType(keyEdgeRuleTriggers).ElementType()
+
+	edgeRule := func(countryCode string) cty.Value {
+		return testObjectVal(resourceEdgeRulesEdgeRule, edgeRuleTy, map[string]cty.Value{
+			keyEdgeRuleActionType: cty.StringVal("block_request"),
+			keyEdgeRuleTriggers: cty.SetVal([]cty.Value{
+				testObjectVal(resourceEdgeRuleTrigger, triggerTy, map[string]cty.Value{
+					keyEdgeRuleTriggerType:                cty.StringVal("country_code"),
+					keyEdgeRuleTriggerPatternMatchingType: cty.StringVal("any"),
+					keyEdgeRuleTriggerPatternMatches:      cty.SetVal([]cty.Value{cty.StringVal(countryCode)}),
+				}),
+			}),
+		})
+	}
+
+	cfg := testObjectVal(r, ty, map[string]cty.Value{
+		keyEdgeRulePullZoneID: cty.NumberIntVal(1),
+		keyEdgeRulesEdgeRules: cty.ListVal([]cty.Value{edgeRule("DE"), edgeRule("XX")}),
+	})
+
+	path := testDiagAttributePath(t, testPlanCreate(t, "bunny_edgerules", cfg))
+
+	want := tftypes.NewAttributePath().
+		WithAttributeName(keyEdgeRulesEdgeRules).
+		WithElementKeyInt(1).
+		WithAttributeName(keyEdgeRuleTriggers)
+	if !path.Equal(want) {
+		t.Errorf("expected the diagnostic for attribute %s, got: %s", want, path)
+	}
+}
+
+func TestEdgeRulesReconcile(t *testing.T) {
+	disabled := func(er *edgeRule) *edgeRule {
+		er.Enabled = ptr.ToBool(false)
+		return er
+	}
+
+	testcases := []struct {
+		name           string
+		current        []*edgeRule
+		wanted         []*edgeRule
+		wantWrites     int
+		wantDeletes    int
+		wantSetEnabled int
+	}{
+		{
+			name:       "insert at front",
+			current:    []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")},
+			wanted:     []*edgeRule{testEdgeRule("", "c"), testEdgeRule("", "a"), testEdgeRule("", "b")},
+			wantWrites: 3,
+		},
+		{
+			name:       "reorder",
+			current:    []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b"), testEdgeRule("c", "c")},
+			wanted:     []*edgeRule{testEdgeRule("", "b"), testEdgeRule("", "a"), testEdgeRule("", "c")},
+			wantWrites: 2,
+		},
+		{
+			name:        "shrink",
+			current:     []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b"), testEdgeRule("c", "c")},
+			wanted:      []*edgeRule{testEdgeRule("", "a"), testEdgeRule("", "b")},
+			wantDeletes: 1,
+		},
+		{
+			name:           "enabled only",
+			current:        []*edgeRule{testEdgeRule("a", "a"), testEdgeRule("b", "b")},
+			wanted:         []*edgeRule{testEdgeRule("", "a"), disabled(testEdgeRule("", "b"))},
+			wantSetEnabled: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeEdgeRuleAPI{edgeRules: tc.current}
+			pm := fake.providerMeta(t, 0)
+
+			current := make([]*edgeRule, 0, len(tc.current))
+			for _, er := range tc.current {
+				cp := *er
+				current = append(current, &cp)
+			}
+
+			wanted := make([]*addOrUpdateEdgeRuleOptions, 0, len(tc.wanted))
+			for _, er := range tc.wanted {
+				wanted = append(wanted, edgeRuleToOptions(er))
+			}
+
+			if err := edgeRulesReconcile(context.Background(), pm, 1, current, wanted); err != nil {
+				t.Fatalf("edgeRulesReconcile failed: %s", err)
+			}
+
+			if !edgeRulesMatch(fake.edgeRules, wanted) {
+				descriptions := make([]string, 0, len(fake.edgeRules))
+				for _, er := range fake.edgeRules {
+					descriptions = append(descriptions, fmt.Sprintf("%s (enabled: %t)", ptr.GetString(er.Description), ptr.GetBool(er.Enabled)))
+				}
+
+				t.Errorf("edge rules differ from the wanted ones after reconciling, got: %s", strings.Join(descriptions, ", "))
+			}
+
+			if fake.writes != tc.wantWrites {
+				t.Errorf("expected %d write requests, got %d", tc.wantWrites, fake.writes)
+			}
+
+			if fake.deletes != tc.wantDeletes {
+				t.Errorf("expected %d delete requests, got %d", tc.wantDeletes, fake.deletes)
+			}
+
+			if fake.setEnabled != tc.wantSetEnabled {
+				t.Errorf("expected %d set enabled requests, got %d", tc.wantSetEnabled, fake.setEnabled)
+			}
+		})
+	}
+}
+
+func TestEdgeRulesFlattenUnsupportedTypes(t *testing.T) {
+	er := testEdgeRule("a", "a", "/a/*")
+	er.ActionType = ptr.ToInt(99)
+	er.Triggers[0].Type = ptr.ToInt(98)
+
+	list, err := edgeRulesFlatten([]*edgeRule{er})
+	if err != nil {
+		t.Fatalf("edgeRulesFlatten failed: %s", err)
+	}
+
+	if v := list[0][keyEdgeRuleActionType]; v != "99" {
+		t.Errorf("expected %s to be the raw value \"99\", got: %q", keyEdgeRuleActionType, v)
+	}
+
+	triggers := list[0][keyEdgeRuleTriggers].([]map[string]interface{})
+	if v := triggers[0][keyEdgeRuleTriggerType]; v != "98" {
+		t.Errorf("expected %s to be the raw value \"98\", got: %q", keyEdgeRuleTriggerType, v)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeRules().Schema, map[string]interface{}{})
+	if err := d.Set(keyEdgeRulesEdgeRules, list); err != nil {
+		t.Fatalf("setting %s failed: %s", keyEdgeRulesEdgeRules, err)
+	}
+
+	wanted, err := edgeRulesFromResource(d)
+	if err != nil {
+		t.Fatalf("edgeRulesFromResource failed: %s", err)
+	}
+
+	if !edgeRulesMatch([]*edgeRule{er}, wanted) {
+		t.Errorf("edge rule with unsupported types changed after flattening and expanding: %+v", wanted[0])
+	}
+}
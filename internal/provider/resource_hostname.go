@@ -99,7 +99,7 @@ func resourceHostname() *schema.Resource {
 }
 
 func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostnameOpt := resourceDataToAddCustomHostnameOption(d)
@@ -141,11 +141,17 @@ func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	hostname, err := resourceHostnameGetByName(ctx, clt, pullZoneID, *hostnameOpt.Hostname)
 	if err != nil {
-		return append(diag, diagsErrFromErr("creating hostname succeeded, retrieving it from api failed", err)...)
+		diag = append(diag, diagsErrFromErr("creating hostname succeeded, retrieving it from api failed", err)...)
+	} else if err := hostnameToResource(hostname, d); err != nil {
+		diag = append(diag, diagsErrFromErr("converting hostname api type to terraform resource failed", err)...)
 	}
 
-	if err := hostnameToResource(hostname, d); err != nil {
-		return append(diag, diagsErrFromErr("converting hostname api type to terraform resource failed", err)...)
+	if diag.HasError() {
+		return createFailed(ctx, d, meta, "hostname", diag, func(ctx context.Context) error {
+			return clt.PullZone.RemoveCustomHostname(ctx, pullZoneID, &bunny.RemoveCustomHostnameOptions{
+				Hostname: hostnameOpt.Hostname,
+			})
+		})
 	}
 
 	return diag
@@ -229,7 +235,7 @@ func resourceDataToAddCustomHostnameOption(d *schema.ResourceData) *bunny.AddCus
 }
 
 func resourceHostnameDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostnameOpt := hostnameFromResource(d)
@@ -245,7 +251,7 @@ func hostnameFromResource(d *schema.ResourceData) *bunny.RemoveCustomHostnameOpt
 }
 
 func resourceHostnameRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	hostnameID, err := getIDAsInt64(d)
 	if err != nil {
@@ -348,7 +354,7 @@ func resourceHostnameUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return nil
 	}
 
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	pullZoneID := int64(d.Get(keyHostnamePullZoneID).(int))
 	hostname := d.Get(keyHostnameHostname).(string)
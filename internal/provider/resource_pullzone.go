@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	ptr "github.com/AlekSi/pointer"
 	bunny "github.com/Aniem-Couple-of-Coders/Go-Module-Bunny"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -67,19 +71,41 @@ const (
 	keyZoneSecurityEnabled               = "zone_security_enabled"
 	keyZoneSecurityIncludeHashRemoteIP   = "zone_security_include_hash_remote_ip"
 
-	keyBlockedReferrers = "blocked_referrers" // uses different API
+	keyBlockedReferrers = "blocked_referrers"
 	keyName             = "name"
 	keyStorageZoneID    = "storage_zone_id"
 	keyZoneSecurityKey  = "zone_security_key"
 
 	keyLastUpdated = "last_updated"
 
+	keyMonthlyBandwidthUsed = "monthly_bandwidth_used"
+	keyMonthlyCharges       = "monthly_charges"
+	keyPriceOverride        = "price_override"
+	keySuspendedReason      = "suspended_reason"
+
 	keySafeHop   = "safehop"
 	keyHeaders   = "headers"
 	keyLimits    = "limits"
 	keyOptimizer = "optimizer"
+	keyOrigin    = "origin"
+	keySecurity  = "security"
+)
+
+var (
+	resourcePullZoneSchemaOnce sync.Once
+	resourcePullZoneSchemaMap  map[string]*schema.Schema
 )
 
+// resourcePullZoneSchema returns the schema of the pull zone resource, it is
+// built on the first call and shared afterwards.
+func resourcePullZoneSchema() map[string]*schema.Schema {
+	resourcePullZoneSchemaOnce.Do(func() {
+		resourcePullZoneSchemaMap = resourcePullZone().Schema
+	})
+
+	return resourcePullZoneSchemaMap
+}
+
 func resourcePullZone() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePullZoneCreate,
@@ -89,27 +115,48 @@ func resourcePullZone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffAll(
+			originValidate,
+			originShorthandDiff,
+			optimizerValidate,
+			pullZoneMaxMonthlyChargesValidate,
+			geoZonesValidate,
+			extraSettingsValidate,
+			copySettingsDiff,
+			pullZoneCrossFieldValidate,
+		),
 
 		Schema: map[string]*schema.Schema{
 			keyAWSSigningEnabled: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the AWS signing should be enabled or not.",
-				Default:     false,
-				Optional:    true,
+				Type:          schema.TypeBool,
+				Description:   "Determines if the AWS signing should be enabled or not.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keyOrigin},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keyOrigin, keyAWSSigningEnabled),
 			},
 			keyAWSSigningKey: {
-				Type:        schema.TypeString,
-				Description: "AWS Signing Key",
-				Optional:    true,
+				Type:          schema.TypeString,
+				Description:   "AWS Signing Key",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keyOrigin},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keyOrigin, keyAWSSigningKey),
 			},
 			keyAWSSigningRegionName: {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keyOrigin},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keyOrigin, keyAWSSigningRegionName),
 			},
 			keyAWSSigningSecret: {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Optional:  true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keyOrigin},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keyOrigin, keyAWSSigningSecret),
 			},
 			keyAllowedReferrers: {
 				Type:        schema.TypeSet,
@@ -118,15 +165,19 @@ func resourcePullZone() *schema.Resource {
 				Optional:    true,
 			},
 			keyBlockPostRequests: {
-				Type:     schema.TypeBool,
-				Default:  false,
-				Optional: true,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyBlockPostRequests),
 			},
 			keyBlockRootPathAccess: {
-				Type:        schema.TypeBool,
-				Default:     false,
-				Description: "Determines if the zone should block requests to the root of the zone.",
-				Optional:    true,
+				Type:          schema.TypeBool,
+				Description:   "Determines if the zone should block requests to the root of the zone.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyBlockRootPathAccess),
 			},
 			keyBlockedCountries: {
 				Type:        schema.TypeSet,
@@ -189,27 +240,35 @@ func resourcePullZone() *schema.Resource {
 				Optional:    true,
 			},
 			keyEnableGeoZoneAF: {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Serve data from the Middle East & Africa Zone.",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Serve data from the Middle East & Africa Zone.",
+				ValidateDiagFunc: warnGeoZonePriceTier("Middle East & Africa"),
 			},
 			keyEnableGeoZoneAsia: {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Serve data from the Asia & Oceania Zone.",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Serve data from the Asia & Oceania Zone.",
+				ValidateDiagFunc: warnGeoZonePriceTier("Asia & Oceania"),
 			},
 			keyEnableGeoZoneEU: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the Europe Zone.",
 			},
 			keyEnableGeoZoneSA: {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Serve data from the South America Zone.",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Serve data from the South America Zone.",
+				ValidateDiagFunc: warnGeoZonePriceTier("South America"),
 			},
 			keyEnableGeoZoneUS: {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
 				Description: "Serve data from the US Zone.",
 			},
@@ -224,6 +283,22 @@ func resourcePullZone() *schema.Resource {
 				Description: "The CNAME domain of the Pull Zone for setting up custom hostnames.",
 				Computed:    true,
 			},
+			keyHostnames: {
+				Type:        schema.TypeList,
+				Description: "All hostnames of the Pull Zone, including the system hostname and hostnames that are not managed by `bunny_hostname` resources.",
+				Computed:    true,
+				Elem:        resourcePullZoneHostname,
+			},
+			keySystemHostname: {
+				Type:        schema.TypeString,
+				Description: "The hostname of the Pull Zone that is controlled by bunny.net, e.g. `myzone.b-cdn.net`.",
+				Computed:    true,
+			},
+			keyCDNURL: {
+				Type:        schema.TypeString,
+				Description: "The HTTPS URL of the system hostname of the Pull Zone, e.g. `https://myzone.b-cdn.net`.",
+				Computed:    true,
+			},
 
 			keyEnableLogging: {
 				Type:        schema.TypeBool,
@@ -244,16 +319,20 @@ func resourcePullZone() *schema.Resource {
 				Optional:    true,
 			},
 			keyEnableTLS1: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the TLS 1 should be enabled on this zone.",
-				Default:     true,
-				Optional:    true,
+				Type:          schema.TypeBool,
+				Description:   "Determines if the TLS 1 should be enabled on this zone.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyEnableTLS1),
 			},
 			keyEnableTLS11: {
-				Type:        schema.TypeBool,
-				Description: "Determines if the TLS 1.1 should be enabled on this zone.",
-				Default:     true,
-				Optional:    true,
+				Type:          schema.TypeBool,
+				Description:   "Determines if the TLS 1.1 should be enabled on this zone.",
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyEnableTLS11),
 			},
 			keyEnableWebPVary: {
 				Type:        schema.TypeBool,
@@ -360,8 +439,17 @@ func resourcePullZone() *schema.Resource {
 			keyOriginURL: {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "The origin URL of the Pull Zone where the files are fetched from.",
-				ExactlyOneOf: []string{keyStorageZoneID},
+				Computed:     true,
+				Description:  fmt.Sprintf("The origin URL of the Pull Zone where the files are fetched from. Shorthand for an %s block of type `url`.", keyOrigin),
+				ExactlyOneOf: []string{keyStorageZoneID, keyOrigin},
+			},
+			keyOrigin: {
+				Type:             schema.TypeList,
+				Description:      "The origin from that the Pull Zone fetches the files.",
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneOrigin,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
 			keyPermaCacheStorageZoneID: {
 				Type:        schema.TypeInt,
@@ -373,6 +461,7 @@ func resourcePullZone() *schema.Resource {
 				Type:             schema.TypeList,
 				MaxItems:         1,
 				Optional:         true,
+				Computed:         true,
 				Elem:             resourcePullZoneSafeHop,
 				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
@@ -380,6 +469,7 @@ func resourcePullZone() *schema.Resource {
 				Type:             schema.TypeList,
 				MaxItems:         1,
 				Optional:         true,
+				Computed:         true,
 				Elem:             resourcePullZoneHeaders,
 				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
@@ -387,6 +477,7 @@ func resourcePullZone() *schema.Resource {
 				Type:             schema.TypeList,
 				MaxItems:         1,
 				Optional:         true,
+				Computed:         true,
 				Elem:             resourcePullZoneLimits,
 				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
@@ -394,9 +485,18 @@ func resourcePullZone() *schema.Resource {
 				Type:             schema.TypeList,
 				MaxItems:         1,
 				Optional:         true,
+				Computed:         true,
 				Elem:             resourcePullZoneOptimizer,
 				DiffSuppressFunc: diffSupressMissingOptionalBlock,
 			},
+			keySecurity: {
+				Type:             schema.TypeList,
+				Description:      "The security settings of the Pull Zone.",
+				MaxItems:         1,
+				Optional:         true,
+				Elem:             resourcePullZoneSecurity,
+				DiffSuppressFunc: diffSupressMissingOptionalBlock,
+			},
 			keyType: {
 				Type:             schema.TypeInt,
 				Optional:         true,
@@ -411,17 +511,31 @@ func resourcePullZone() *schema.Resource {
 				Optional:    true,
 			},
 			keyZoneSecurityEnabled: {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyZoneSecurityEnabled),
 			},
 			keyZoneSecurityIncludeHashRemoteIP: {
-				Type:     schema.TypeBool,
-				Optional: true,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{keySecurity},
+				Deprecated:    fmt.Sprintf("use %s.%s instead", keySecurity, keyZoneSecurityIncludeHashRemoteIP),
 			},
 
 			keyEnabled: {
-				Type:     schema.TypeBool,
+				Type:             schema.TypeBool,
+				Description:      fmt.Sprintf("Determines if the Pull Zone serves requests. Differences are ignored while the zone is suspended because the monthly bandwidth limit is reached, see `%s`.", keySuspendedReason),
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: diffSuppressEnabledWhileLimitSuspended,
+			},
+			keySuspendedReason: {
+				Type: schema.TypeString,
+				Description: "The reason why the Pull Zone does not serve requests. Empty if the zone is enabled or was disabled via `enabled`.\nValues: " +
+					strings.Join([]string{suspendedReasonMonthlyBandwidthLimit, suspendedReasonAccount}, ", "),
 				Computed: true,
 			},
 			keyName: {
@@ -433,8 +547,8 @@ func resourcePullZone() *schema.Resource {
 			keyStorageZoneID: {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
-				Description: "The ID of the storage zone that the Pull Zone is linked to.",
+				Computed:    true,
+				Description: fmt.Sprintf("The ID of the storage zone that the Pull Zone is linked to. Shorthand for an %s block of type `storage_zone`.", keyOrigin),
 			},
 			keyZoneSecurityKey: {
 				Type:      schema.TypeString,
@@ -445,27 +559,43 @@ func resourcePullZone() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Computed:    true, // must be updated via Add/Remove Blocked Referrer API Endpoint, not implemented
 				Description: "The list of hostnames that will be blocked from accessing the Pull Zone.",
 			},
 
+			keyExtraSettingsJSON: resourcePullZoneExtraSettingsJSONSchema,
+
+			keyCopySettingsFrom: resourcePullZoneCopySettingsSchema[keyCopySettingsFrom],
+			keyCopyEdgeRules:    resourcePullZoneCopySettingsSchema[keyCopyEdgeRules],
+			keyCopiedSettings:   resourcePullZoneCopySettingsSchema[keyCopiedSettings],
+
 			keyLastUpdated: {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			keyMonthlyBandwidthUsed: {
+				Type:        schema.TypeInt,
+				Description: "The amount of bandwidth in bytes that was used by the Pull Zone in the current month.",
+				Computed:    true,
+			},
+			keyMonthlyCharges: {
+				Type:        schema.TypeFloat,
+				Description: "The charges of the Pull Zone in the current month, in USD.",
+				Computed:    true,
+			},
+			keyPriceOverride: {
+				Type:        schema.TypeFloat,
+				Description: "The custom price per GB of the Pull Zone, in USD. 0 if the regular pricing applies.",
+				Computed:    true,
+			},
 		},
 	}
 }
 
 func resourcePullZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
-	pz, err := clt.PullZone.Add(ctx, &bunny.PullZoneAddOptions{
-		Name:          d.Get(keyName).(string),
-		OriginURL:     d.Get(keyOriginURL).(string),
-		StorageZoneID: getInt64Ptr(d, keyStorageZoneID),
-		Type:          d.Get(keyType).(int),
-	})
+	pz, err := clt.PullZone.Add(ctx, pullZoneAddOptionsFromResource(d))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("creating pull zone failed: %w", err))
 	}
@@ -485,7 +615,7 @@ func resourcePullZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 			Summary:  "setting pull zone attributes via update failed",
 		})
 
-		if err := pullZoneToResource(pz, d); err != nil {
+		if err := pullZoneToResource(&pullZone{PullZone: *pz}, d); err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "converting api-type to resource data failed: " + err.Error(),
@@ -493,14 +623,24 @@ func resourcePullZoneCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 		}
 
-		return diags
+		return createFailed(ctx, d, meta, "pull zone", diags, func(ctx context.Context) error {
+			return clt.PullZone.Delete(ctx, *pz.ID)
+		})
+	}
+
+	if d.Get(keyCopyEdgeRules).(bool) {
+		if diags := pullZoneCopyEdgeRules(ctx, d, meta, *pz.ID); diags.HasError() {
+			return createFailed(ctx, d, meta, "pull zone", diags, func(ctx context.Context) error {
+				return clt.PullZone.Delete(ctx, *pz.ID)
+			})
+		}
 	}
 
 	return nil
 }
 
 func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).api
 
 	pullZone, err := pullZoneFromResource(d)
 	if err != nil {
@@ -512,7 +652,26 @@ func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	updatedPullZone, err := clt.PullZone.Update(ctx, id, pullZone)
+	// on creation HasChange is false when enabled is set to its zero value
+	if (d.IsNewResource() || d.HasChange(keyEnabled)) && isConfigured(d, keyEnabled) {
+		enabled := d.Get(keyEnabled).(bool)
+		if err := clt.pullZoneSetEnabled(ctx, id, enabled); err != nil {
+			d.Partial(true)
+			return diagsErrFromErr(fmt.Sprintf("setting pull zone enabled to %t failed", enabled), err)
+		}
+	}
+
+	// blocked referrers and IPs must be changed entry-wise via separate
+	// API endpoints, the changes are applied first to retrieve them with
+	// the result of the pull zone update
+	for _, u := range pullZoneBlockListUpdaters(clt) {
+		if err := u.update(ctx, d, id); err != nil {
+			d.Partial(true)
+			return diagsErrFromErr("updating pull zone via API failed", err)
+		}
+	}
+
+	updatedPullZone, err := clt.pullZoneUpdate(ctx, id, pullZone)
 	if err != nil {
 		return diagsErrFromErr("updating pull zone via API failed", err)
 	}
@@ -532,14 +691,14 @@ func resourcePullZoneUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourcePullZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).api
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	pz, err := clt.PullZone.Get(ctx, id)
+	pz, err := clt.pullZoneGet(ctx, id)
 	if err != nil {
 		return diagsErrFromErr("could not retrieve pull zone", err)
 	}
@@ -552,7 +711,7 @@ func resourcePullZoneRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourcePullZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
@@ -569,8 +728,97 @@ func resourcePullZoneDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// pullZoneMaxMonthlyChargesValidate fails if the month-to-date charges of an
+// existing Pull Zone exceed the max_monthly_charges provider setting.
+// The charges are the ones that were retrieved when the state was refreshed.
+func pullZoneMaxMonthlyChargesValidate(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	pm, ok := meta.(*providerMeta)
+	if !ok || pm.maxMonthlyCharges == 0 || d.Id() == "" {
+		return nil
+	}
+
+	charges, _ := d.GetChange(keyMonthlyCharges)
+	if charges.(float64) > pm.maxMonthlyCharges {
+		return fmt.Errorf("month-to-date charges of pull zone %s (%.2f USD) exceed the provider setting %s (%.2f USD), "+
+			"raise %s to apply changes to the pull zone",
+			d.Id(), charges.(float64), keyMaxMonthlyCharges, pm.maxMonthlyCharges, keyMaxMonthlyCharges,
+		)
+	}
+
+	return nil
+}
+
+const (
+	suspendedReasonMonthlyBandwidthLimit = "monthly_bandwidth_limit"
+	suspendedReasonAccount               = "account_suspended"
+)
+
+// pullZoneSuspendedReason returns the reason why the pull zone does not serve
+// requests, or an empty string if it is enabled or was disabled by the
+// user.
+func pullZoneSuspendedReason(pz *pullZone) string {
+	if ptr.GetBool(pz.Suspended) {
+		return suspendedReasonAccount
+	}
+
+	limit := ptr.GetInt64(pz.MonthlyBandwidthLimit)
+	if !ptr.GetBool(pz.Enabled) && limit > 0 && ptr.GetInt64(pz.MonthlyBandwidthUsed) >= limit {
+		return suspendedReasonMonthlyBandwidthLimit
+	}
+
+	return ""
+}
+
+// diffSuppressEnabledWhileLimitSuspended suppresses enabling a Pull Zone that
+// was disabled because its monthly bandwidth limit is reached. The zone was
+// not disabled by the user, it must be resolved by raising the limit.
+func diffSuppressEnabledWhileLimitSuspended(_, old, new string, d *schema.ResourceData) bool {
+	return old == "false" && new == "true" &&
+		d.Get(keySuspendedReason).(string) == suspendedReasonMonthlyBandwidthLimit
+}
+
+// geoZonesValidate ensures that at least one geo zone stays enabled.
+// Geo zones with values that are unknown during planning are considered as
+// enabled.
+func geoZonesValidate(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	keys := []string{
+		keyEnableGeoZoneAF,
+		keyEnableGeoZoneAsia,
+		keyEnableGeoZoneEU,
+		keyEnableGeoZoneSA,
+		keyEnableGeoZoneUS,
+	}
+
+	for _, key := range keys {
+		if !d.NewValueKnown(key) || d.Get(key).(bool) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("at least one of %s must be enabled", strings.Join(keys, ", "))
+}
+
+// warnGeoZonePriceTier returns a validation function that emits a warning
+// when the geo zone is enabled, traffic of the zone is billed at a higher
+// price tier than traffic in Europe and North America.
+func warnGeoZonePriceTier(zoneName string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		if enabled, ok := i.(bool); !ok || !enabled {
+			return nil
+		}
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s geo zone is enabled", zoneName),
+			Detail: fmt.Sprintf("Traffic served from the %s zone is billed at a higher price tier than traffic served from Europe and North America. "+
+				"Disable the zone to reduce costs if it is not required.", zoneName),
+			AttributePath: path,
+		}}
+	}
+}
+
 // pullZoneToResource sets fields in d to the values in pz.
-func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
+func pullZoneToResource(pz *pullZone, d *schema.ResourceData) error {
 	if pz.ID != nil {
 		d.SetId(strconv.FormatInt(*pz.ID, 10))
 	}
@@ -647,6 +895,9 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	if err := d.Set(keyCnameDomain, pz.CnameDomain); err != nil {
 		return err
 	}
+	if err := hostnamesToResource(&pz.PullZone, d); err != nil {
+		return err
+	}
 	if err := d.Set(keyEnableLogging, pz.EnableLogging); err != nil {
 		return err
 	}
@@ -743,87 +994,180 @@ func pullZoneToResource(pz *bunny.PullZone, d *schema.ResourceData) error {
 	if err := d.Set(keyEnabled, pz.Enabled); err != nil {
 		return err
 	}
+	if err := d.Set(keySuspendedReason, pullZoneSuspendedReason(pz)); err != nil {
+		return err
+	}
 	if err := d.Set(keyName, pz.Name); err != nil {
 		return err
 	}
 	if err := d.Set(keyZoneSecurityKey, pz.ZoneSecurityKey); err != nil {
 		return err
 	}
-
-	if err := safeHopToResource(pz, d); err != nil {
+	if err := d.Set(keyMonthlyBandwidthUsed, pz.MonthlyBandwidthUsed); err != nil {
+		return err
+	}
+	if err := d.Set(keyMonthlyCharges, pz.MonthlyCharges); err != nil {
+		return err
+	}
+	if err := d.Set(keyPriceOverride, pz.PriceOverride); err != nil {
 		return err
 	}
 
-	if err := headersToResource(pz, d); err != nil {
+	if err := originToResource(pz, d); err != nil {
 		return err
 	}
 
-	if err := limitsToResource(pz, d); err != nil {
+	if err := securityToResource(pz, d); err != nil {
 		return err
 	}
 
-	if err := d.Set(keyOptimizer, optimizerFlatten(pz, d)); err != nil {
+	if err := extraSettingsToResource(pz, d); err != nil {
 		return err
 	}
 
-	return nil
+	return pullZoneCopyableBlocksToResource(pz, d)
 }
 
-// pullZoneFromResource returns a PullZoneUpdateOptions API type that
+// pullZoneAddOptionsFromResource returns a PullZoneAddOptions API type that
+// has the origin and the immutable fields set to the values in d.
+func pullZoneAddOptionsFromResource(d *schema.ResourceData) *bunny.PullZoneAddOptions {
+	res := bunny.PullZoneAddOptions{
+		Name: d.Get(keyName).(string),
+		Type: d.Get(keyType).(int),
+	}
+
+	if !isBlockConfigured(d, keyOrigin) {
+		res.OriginURL = d.Get(keyOriginURL).(string)
+		res.StorageZoneID = getInt64Ptr(d, keyStorageZoneID)
+
+		return &res
+	}
+
+	// The origin URL is only set for origin types that use it, the
+	// edge_script origin type is set by the subsequent update.
+	m := structureFromResource(d, keyOrigin)
+	switch pullZoneOriginTypesStr[m.getStr(keyOriginBlockType)] {
+	case pullZoneOriginTypeURL, pullZoneOriginTypeDNSAccelerated:
+		res.OriginURL = m.getStr(keyOriginBlockURL)
+	case pullZoneOriginTypeStorageZone:
+		res.StorageZoneID = m.getInt64Ptr(keyOriginBlockStorageZoneID)
+	}
+
+	return &res
+}
+
+// pullZoneFromResource returns a pullZoneUpdateOptions API type that
 // has fields set to the values in d.
-func pullZoneFromResource(d *schema.ResourceData) (*bunny.PullZoneUpdateOptions, error) {
-	var res bunny.PullZoneUpdateOptions
-
-	res.AWSSigningEnabled = getBoolPtr(d, keyAWSSigningEnabled)
-	res.AWSSigningKey = getStrPtr(d, keyAWSSigningKey)
-	res.AWSSigningRegionName = getStrPtr(d, keyAWSSigningRegionName)
-	res.AWSSigningSecret = getStrPtr(d, keyAWSSigningSecret)
-	res.AllowedReferrers = getStrSetAsSlice(d, keyAllowedReferrers)
-	res.BlockPostRequests = getBoolPtr(d, keyBlockPostRequests)
-	res.BlockRootPathAccess = getBoolPtr(d, keyBlockRootPathAccess)
-	res.BlockedCountries = getStrSetAsSlice(d, keyBlockedCountries)
-	res.BlockedIPs = getStrSetAsSlice(d, keyBlockedIPs)
-	res.BudgetRedirectedCountries = getStrSetAsSlice(d, keyBudgetRedirectedCountries)
-	res.CacheControlBrowserMaxAgeOverride = getInt64Ptr(d, keyCacheControlBrowserMaxAgeOverride)
-	res.CacheControlMaxAgeOverride = getInt64Ptr(d, keyCacheControlMaxAgeOverride)
-	res.CacheErrorResponses = getBoolPtr(d, keyCacheErrorResponses)
-	res.DisableCookies = getBoolPtr(d, keyDisableCookies)
-	res.EnableAvifVary = getBoolPtr(d, keyEnableAvifVary)
-	res.EnableCacheSlice = getBoolPtr(d, keyEnableCacheSlice)
-	res.EnableCountryCodeVary = getBoolPtr(d, keyEnableCountryCodeVary)
-	res.EnableHostnameVary = getBoolPtr(d, keyEnableHostnameVary)
-	res.EnableLogging = getBoolPtr(d, keyEnableLogging)
-	res.EnableMobileVary = getBoolPtr(d, keyEnableMobileVary)
-	res.EnableOriginShield = getBoolPtr(d, keyEnableOriginShield)
-	res.EnableTLS1 = getBoolPtr(d, keyEnableTLS1)
-	res.EnableTLS11 = getBoolPtr(d, keyEnableTLS11)
-	res.EnableWebPVary = getBoolPtr(d, keyEnableWebPVary)
-	res.ErrorPageCustomCode = getStrPtr(d, keyErrorPageCustomCode)
-	res.ErrorPageEnableCustomCode = getBoolPtr(d, keyErrorPageEnableCustomCode)
-	res.ErrorPageEnableStatuspageWidget = getBoolPtr(d, keyErrorPageEnableStatuspageWidget)
-	res.ErrorPageStatuspageCode = getStrPtr(d, keyErrorPageStatuspageCode)
-	res.ErrorPageWhitelabel = getBoolPtr(d, keyErrorPageWhitelabel)
-	res.FollowRedirects = getBoolPtr(d, keyFollowRedirects)
-	res.IgnoreQueryStrings = getBoolPtr(d, keyIgnoreQueryStrings)
-	res.LogForwardingEnabled = getBoolPtr(d, keyLogForwardingEnabled)
-	res.LogForwardingHostname = getStrPtr(d, keyLogForwardingHostname)
-	res.LogForwardingPort = getInt32Ptr(d, keyLogForwardingPort)
-	res.LogForwardingToken = getStrPtr(d, keyLogForwardingToken)
-	res.LoggingIPAnonymizationEnabled = getBoolPtr(d, keyLoggingIPAnonymizationEnabled)
-	res.LoggingSaveToStorage = getBoolPtr(d, keyLoggingSaveToStorage)
-	res.LoggingStorageZoneID = getInt64Ptr(d, keyLoggingStorageZoneID)
-	res.OriginShieldZoneCode = getStrPtr(d, keyOriginShieldZoneCode)
-	res.OriginURL = getStrPtr(d, keyOriginURL)
-	res.PermaCacheStorageZoneID = getInt64Ptr(d, keyPermaCacheStorageZoneID)
-	res.Type = getIntPtr(d, keyType)
-	res.VerifyOriginSSL = getBoolPtr(d, keyVerifyOriginSSL)
-	res.ZoneSecurityEnabled = getBoolPtr(d, keyZoneSecurityEnabled)
-	res.ZoneSecurityIncludeHashRemoteIP = getBoolPtr(d, keyZoneSecurityIncludeHashRemoteIP)
-
-	safehopFromResource(&res, d)
-	headersFromResource(&res, d)
-	limitsFromResource(&res, d)
-	optimizerFromResource(&res, d)
+// Only fields of attributes and blocks that changed are set, when the
+// resource is created the fields of configured attributes and attributes
+// with default values are set. This prevents overwriting settings that were
+// changed concurrently or are not managed by the configuration.
+func pullZoneFromResource(d *schema.ResourceData) (*pullZoneUpdateOptions, error) {
+	var res pullZoneUpdateOptions
+
+	c := newChangedAttrGetter(d, resourcePullZoneSchema())
+
+	if isBlockConfigured(d, keyOrigin) {
+		if c.changed(keyOrigin) {
+			if err := originFromResource(&res, d); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		res.AWSSigningEnabled = c.boolPtr(keyAWSSigningEnabled)
+		res.AWSSigningKey = c.strPtr(keyAWSSigningKey)
+		res.AWSSigningRegionName = c.strPtr(keyAWSSigningRegionName)
+		res.AWSSigningSecret = c.strPtr(keyAWSSigningSecret)
+
+		if storageZoneID := d.Get(keyStorageZoneID).(int); storageZoneID > 0 {
+			if d.HasChange(keyStorageZoneID) {
+				res.OriginType = ptr.ToInt32(pullZoneOriginTypeStorageZone)
+				res.StorageZoneID = ptr.ToInt64(int64(storageZoneID))
+			}
+		} else {
+			res.OriginURL = c.strPtr(keyOriginURL)
+			if d.HasChange(keyStorageZoneID) {
+				res.OriginType = ptr.ToInt32(pullZoneOriginTypeURL)
+			}
+		}
+	}
+
+	if isBlockConfigured(d, keySecurity) {
+		if c.changed(keySecurity) {
+			if err := securityFromResource(&res, d); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		res.BlockPostRequests = c.boolPtr(keyBlockPostRequests)
+		res.BlockRootPathAccess = c.boolPtr(keyBlockRootPathAccess)
+		res.EnableTLS1 = c.boolPtr(keyEnableTLS1)
+		res.EnableTLS11 = c.boolPtr(keyEnableTLS11)
+		res.ZoneSecurityEnabled = c.boolPtr(keyZoneSecurityEnabled)
+		res.ZoneSecurityIncludeHashRemoteIP = c.boolPtr(keyZoneSecurityIncludeHashRemoteIP)
+	}
+
+	res.AllowedReferrers = c.strSetAsSlice(keyAllowedReferrers)
+	res.BlockedCountries = c.strSetAsSlice(keyBlockedCountries)
+	res.BudgetRedirectedCountries = c.strSetAsSlice(keyBudgetRedirectedCountries)
+	res.CacheControlBrowserMaxAgeOverride = c.int64Ptr(keyCacheControlBrowserMaxAgeOverride)
+	res.CacheControlMaxAgeOverride = c.int64Ptr(keyCacheControlMaxAgeOverride)
+	res.CacheErrorResponses = c.boolPtr(keyCacheErrorResponses)
+	res.DisableCookies = c.boolPtr(keyDisableCookies)
+	res.EnableAvifVary = c.boolPtr(keyEnableAvifVary)
+	res.EnableCacheSlice = c.boolPtr(keyEnableCacheSlice)
+	res.EnableCountryCodeVary = c.boolPtr(keyEnableCountryCodeVary)
+	res.EnableGeoZoneAF = c.boolPtr(keyEnableGeoZoneAF)
+	res.EnableGeoZoneAsia = c.boolPtr(keyEnableGeoZoneAsia)
+	res.EnableGeoZoneEU = c.boolPtr(keyEnableGeoZoneEU)
+	res.EnableGeoZoneSA = c.boolPtr(keyEnableGeoZoneSA)
+	res.EnableGeoZoneUS = c.boolPtr(keyEnableGeoZoneUS)
+	res.EnableHostnameVary = c.boolPtr(keyEnableHostnameVary)
+	res.EnableLogging = c.boolPtr(keyEnableLogging)
+	res.EnableMobileVary = c.boolPtr(keyEnableMobileVary)
+	res.EnableOriginShield = c.boolPtr(keyEnableOriginShield)
+	res.EnableWebPVary = c.boolPtr(keyEnableWebPVary)
+	res.ErrorPageCustomCode = c.strPtr(keyErrorPageCustomCode)
+	res.ErrorPageEnableCustomCode = c.boolPtr(keyErrorPageEnableCustomCode)
+	res.ErrorPageEnableStatuspageWidget = c.boolPtr(keyErrorPageEnableStatuspageWidget)
+	res.ErrorPageStatuspageCode = c.strPtr(keyErrorPageStatuspageCode)
+	res.ErrorPageWhitelabel = c.boolPtr(keyErrorPageWhitelabel)
+	res.FollowRedirects = c.boolPtr(keyFollowRedirects)
+	res.IgnoreQueryStrings = c.boolPtr(keyIgnoreQueryStrings)
+	res.LogForwardingEnabled = c.boolPtr(keyLogForwardingEnabled)
+	res.LogForwardingHostname = c.strPtr(keyLogForwardingHostname)
+	res.LogForwardingPort = c.int32Ptr(keyLogForwardingPort)
+	res.LogForwardingToken = c.strPtr(keyLogForwardingToken)
+	res.LoggingIPAnonymizationEnabled = c.boolPtr(keyLoggingIPAnonymizationEnabled)
+	res.LoggingSaveToStorage = c.boolPtr(keyLoggingSaveToStorage)
+	res.LoggingStorageZoneID = c.int64Ptr(keyLoggingStorageZoneID)
+	res.OriginShieldZoneCode = c.strPtr(keyOriginShieldZoneCode)
+	res.PermaCacheStorageZoneID = c.int64Ptr(keyPermaCacheStorageZoneID)
+	res.Type = c.intPtr(keyType)
+	res.VerifyOriginSSL = c.boolPtr(keyVerifyOriginSSL)
+
+	// blocks that were copied from a template Pull Zone are not configured
+	// but must be sent when the Pull Zone is created
+	if c.changed(keySafeHop) || isCopiedSetting(d, keySafeHop) {
+		safehopFromResource(&res.PullZoneUpdateOptions, d)
+	}
+	if c.changed(keyHeaders) || isCopiedSetting(d, keyHeaders) {
+		headersFromResource(&res.PullZoneUpdateOptions, d)
+	}
+	if c.changed(keyLimits) || isCopiedSetting(d, keyLimits) {
+		limitsFromResource(&res.PullZoneUpdateOptions, d)
+	}
+	if c.changed(keyOptimizer) || isCopiedSetting(d, keyOptimizer) {
+		optimizerFromResource(&res, d)
+	}
+
+	// the extra settings are merged into the request body after the
+	// modeled fields when it is marshaled
+	if c.changed(keyExtraSettingsJSON) {
+		if err := extraSettingsFromResource(&res, d); err != nil {
+			return nil, err
+		}
+	}
 
 	return &res, nil
 }
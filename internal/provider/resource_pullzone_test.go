@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -163,7 +164,6 @@ func TestAccPullZone_basic(t *testing.T) {
 	/*
 	   TODO:
 	   - set a TypeList field and check if it was set correctly
-	   - test updating keyBlockedReferrers, should recreate the pz
 	   - only set required values in this test (https://github.com/hashicorp/terraform-provider-google/wiki/Developer-Best-Practices#acceptance-tests)
 
 	*/
@@ -240,6 +240,7 @@ func TestAccPullZone_full(t *testing.T) {
 		BlockRootPathAccess:               ptr.ToBool(true),
 		BlockedCountries:                  []string{"KP", "US"},
 		BlockedIPs:                        []string{"1.1.1.1", "127.0.0.1", "::1"},
+		BlockedReferrers:                  []string{"evil.example.com", "hotlinker.example.com"},
 		BudgetRedirectedCountries:         []string{"DE", "GB"},
 		CacheControlBrowserMaxAgeOverride: ptr.ToInt64(100),
 		CacheControlMaxAgeOverride:        ptr.ToInt64(3),
@@ -364,7 +365,7 @@ resource "bunny_pullzone" "%s" {
 	verify_origin_ssl = %t
 	zone_security_enabled = %t
 	zone_security_include_hash_remote_ip = %t
-	# blocked_referrers // uses different API
+	blocked_referrers = %s
 	name = "%s"
 	# storage_zone_id
 	# zone_security_key
@@ -463,6 +464,7 @@ resource "bunny_pullzone" "%s" {
 		ptr.GetBool(attrs.VerifyOriginSSL),
 		ptr.GetBool(attrs.ZoneSecurityEnabled),
 		ptr.GetBool(attrs.ZoneSecurityIncludeHashRemoteIP),
+		tfStrList(attrs.BlockedReferrers),
 		ptr.GetString(attrs.Name),
 
 		ptr.GetBool(attrs.EnableSafeHop),
@@ -708,7 +710,6 @@ func strSliceDiff(a, b []string) string {
 // pullZoneDiffIgnoredFields contains a list of fieldsnames in a bunny.PullZone struct that are ignored by pzDiff.
 var pullZoneDiffIgnoredFields = map[string]struct{}{
 	"AccessControlOriginHeaderExtensions": {}, // computed field
-	"BlockedReferrers":                    {}, // computed field
 	"CnameDomain":                         {}, // computed field
 	"EnableGeoZoneAF":                     {}, // computed field
 	"EnableGeoZoneAsia":                   {}, // computed field
@@ -791,3 +792,886 @@ resource "bunny_pullzone" "testpz" {
 		},
 	})
 }
+
+// checkPullZoneAPI retrieves the pull zone of the resource from the API and
+// passes it to checkFn.
+func checkPullZoneAPI(resourceName string, checkFn func(*bunny.PullZone) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		strID, err := idFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(strID)
+		if err != nil {
+			return fmt.Errorf("could not convert resource ID %q to int64: %w", strID, err)
+		}
+
+		pz, err := newAPIClient().PullZone.Get(context.Background(), int64(id))
+		if err != nil {
+			return fmt.Errorf("fetching pull-zone with id %d from api client failed: %w", id, err)
+		}
+
+		return checkFn(pz)
+	}
+}
+
+func TestAccPullZone_originBlock(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(hostHeader string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+
+	origin {
+		type = "url"
+		url = "https://s3.eu-central-1.amazonaws.com"
+		host_header = "%s"
+		aws_signing_enabled = true
+		aws_signing_key = "12345"
+		aws_signing_secret = "456"
+		aws_signing_region_name = "eu-central-1"
+	}
+}`, pzName, hostHeader)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf("mybucket.s3.eu-central-1.amazonaws.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "origin.0.type", "url"),
+					resource.TestCheckResourceAttr(resourceName, "origin.0.host_header", "mybucket.s3.eu-central-1.amazonaws.com"),
+					resource.TestCheckResourceAttr(resourceName, "origin_url", "https://s3.eu-central-1.amazonaws.com"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if err := stringsAreEqual("mybucket.s3.eu-central-1.amazonaws.com", pz.OriginHostHeader); err != nil {
+							return fmt.Errorf("OriginHostHeader differs: %w", err)
+						}
+
+						if diff := int32Diff(ptr.ToInt32(pullZoneOriginTypeURL), pz.OriginType); diff != "" {
+							return fmt.Errorf("OriginType differs: %s", diff)
+						}
+
+						if err := boolsAreEqual(true, pz.AWSSigningEnabled); err != nil {
+							return fmt.Errorf("AWSSigningEnabled differs: %w", err)
+						}
+
+						return stringsAreEqual("eu-central-1", pz.AWSSigningRegionName)
+					}),
+				),
+			},
+			{
+				Config: tf("otherbucket.s3.eu-central-1.amazonaws.com"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "origin.0.host_header", "otherbucket.s3.eu-central-1.amazonaws.com"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_originBlockAWSSigningFieldsMustBeSetTogether(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+
+	origin {
+		type = "url"
+		url = "https://bunny.net"
+		aws_signing_enabled = true
+		aws_signing_key = "12345"
+	}
+}`, randResourceName()),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("aws_signing_secret must be set together with"),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_securityBlock(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(ddosType string, blockPostRequests bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	security {
+		ddos_protection_enabled = true
+		ddos_protection_type = "%s"
+		auto_ssl_enabled = true
+		block_post_requests = %t
+		block_root_path_access = true
+		enable_tlsv1 = false
+		enable_tls1_1 = false
+	}
+}`, pzName, ddosType, blockPostRequests)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf("active_standard", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "security.0.ddos_protection_type", "active_standard"),
+					resource.TestCheckResourceAttr(resourceName, "security.0.block_post_requests", "true"),
+					resource.TestCheckResourceAttr(resourceName, "block_post_requests", "true"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if diff := intDiff(ptr.ToInt(pullZoneDDoSProtectionTypeActiveStandard), pz.ShieldDDosProtectionType); diff != "" {
+							return fmt.Errorf("ShieldDDosProtectionType differs: %s", diff)
+						}
+
+						if err := boolsAreEqual(true, pz.EnableAutoSSL); err != nil {
+							return fmt.Errorf("EnableAutoSSL differs: %w", err)
+						}
+
+						if err := boolsAreEqual(true, pz.BlockPostRequests); err != nil {
+							return fmt.Errorf("BlockPostRequests differs: %w", err)
+						}
+
+						return boolsAreEqual(false, pz.EnableTLS1)
+					}),
+				),
+			},
+			{
+				Config: tf("active_aggressive", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "security.0.ddos_protection_type", "active_aggressive"),
+					resource.TestCheckResourceAttr(resourceName, "security.0.block_post_requests", "false"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_securityBlockInvalidDDoSProtectionType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	security {
+		ddos_protection_type = "invalid"
+	}
+}`, randResourceName()),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected ddos_protection_type to be one of"),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_optimizerImageClasses(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(thumbWidth int) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	optimizer {
+		enabled = true
+		force_classes = true
+
+		image_class {
+			name = "thumb"
+			width = %d
+			height = 200
+			quality = 80
+		}
+
+		image_class {
+			name = "banner"
+			crop = "1200,300"
+			properties = {
+				sharpen = "true"
+			}
+		}
+	}
+}`, pzName, thumbWidth)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.force_classes", "true"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.0.name", "thumb"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.0.width", "200"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.1.name", "banner"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.1.crop", "1200,300"),
+					resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.1.properties.sharpen", "true"),
+				),
+			},
+			{
+				Config: tf(150),
+				Check:  resource.TestCheckResourceAttr(resourceName, "optimizer.0.image_class.0.width", "150"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_optimizerImageClassNamesMustBeUnique(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	optimizer {
+		image_class {
+			name = "thumb"
+			width = 100
+		}
+
+		image_class {
+			name = "thumb"
+			width = 200
+		}
+	}
+}`, randResourceName()),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`image class name "thumb" is not unique`),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_usageAttributes(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bunny" {
+	max_monthly_charges = 1
+}
+
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+}`, pzName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "monthly_bandwidth_used", "0"),
+					resource.TestCheckResourceAttr(resourceName, "monthly_charges", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "price_override"),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_geoZones(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(enableSA bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	enable_geo_zone_af = false
+	enable_geo_zone_sa = %t
+}`, pzName, enableSA)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enable_geo_zone_af", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enable_geo_zone_sa", "false"),
+					resource.TestCheckResourceAttr(resourceName, "enable_geo_zone_eu", "true"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if err := boolsAreEqual(false, pz.EnableGeoZoneAF); err != nil {
+							return fmt.Errorf("EnableGeoZoneAF differs: %w", err)
+						}
+
+						return boolsAreEqual(false, pz.EnableGeoZoneSA)
+					}),
+				),
+			},
+			{
+				Config: tf(true),
+				Check:  resource.TestCheckResourceAttr(resourceName, "enable_geo_zone_sa", "true"),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_geoZonesOneMustBeEnabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	enable_geo_zone_af = false
+	enable_geo_zone_asia = false
+	enable_geo_zone_eu = false
+	enable_geo_zone_sa = false
+	enable_geo_zone_us = false
+}`, randResourceName()),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("at least one of enable_geo_zone_af, .* must be enabled"),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_blockedReferrersAndIPs(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(referrers, ips []string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	blocked_referrers = %s
+	blocked_ips = %s
+}`, pzName, tfStrList(referrers), tfStrList(ips))
+	}
+
+	checkAPI := func(referrers, ips []string) resource.TestCheckFunc {
+		return checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+			sort.Strings(pz.BlockedReferrers)
+			sort.Strings(pz.BlockedIPs)
+
+			if diff := strSliceDiff(referrers, pz.BlockedReferrers); diff != "" {
+				return fmt.Errorf("BlockedReferrers differ: %s", diff)
+			}
+
+			if diff := strSliceDiff(ips, pz.BlockedIPs); diff != "" {
+				return fmt.Errorf("BlockedIPs differ: %s", diff)
+			}
+
+			return nil
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf([]string{"a.example.com", "b.example.com"}, []string{"192.0.2.1"}),
+				Check:  checkAPI([]string{"a.example.com", "b.example.com"}, []string{"192.0.2.1"}),
+			},
+			{
+				Config: tf([]string{"b.example.com", "c.example.com"}, []string{"192.0.2.2", "192.0.2.3"}),
+				Check:  checkAPI([]string{"b.example.com", "c.example.com"}, []string{"192.0.2.2", "192.0.2.3"}),
+			},
+			{
+				Config: tf([]string{}, []string{}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "blocked_referrers.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "blocked_ips.#", "0"),
+					checkAPI(nil, nil),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+// checkPullZoneIDUnchanged stores the ID of the resource in id if it is
+// empty and otherwise fails if the ID of the resource differs from id.
+func checkPullZoneIDUnchanged(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		curID, err := idFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		if *id == "" {
+			*id = curID
+			return nil
+		}
+
+		if *id != curID {
+			return fmt.Errorf("pull zone was replaced, id changed from %s to %s", *id, curID)
+		}
+
+		return nil
+	}
+}
+
+func TestAccPullZone_changeStorageZoneInPlace(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+	sz1Name := randResourceName()
+	sz2Name := randResourceName()
+	var pzID string
+
+	storageZones := fmt.Sprintf(`
+resource "bunny_storagezone" "sz1" {
+	name = "%s"
+	region = "DE"
+}
+
+resource "bunny_storagezone" "sz2" {
+	name = "%s"
+	region = "DE"
+}
+`, sz1Name, sz2Name)
+
+	tfStorageZone := func(storageZoneResource string) string {
+		return storageZones + fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	storage_zone_id = bunny_storagezone.%s.id
+}`, pzName, storageZoneResource)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tfStorageZone("sz1"),
+				Check: resource.ComposeTestCheckFunc(
+					checkPullZoneIDUnchanged(resourceName, &pzID),
+					resource.TestCheckResourceAttrPair(resourceName, "storage_zone_id", "bunny_storagezone.sz1", "id"),
+				),
+			},
+			{
+				Config: tfStorageZone("sz2"),
+				Check: resource.ComposeTestCheckFunc(
+					checkPullZoneIDUnchanged(resourceName, &pzID),
+					resource.TestCheckResourceAttrPair(resourceName, "storage_zone_id", "bunny_storagezone.sz2", "id"),
+				),
+			},
+			{
+				Config: storageZones + fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+}`, pzName),
+				Check: resource.ComposeTestCheckFunc(
+					checkPullZoneIDUnchanged(resourceName, &pzID),
+					resource.TestCheckResourceAttr(resourceName, "origin_url", "https://bunny.net"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if diff := int32Diff(ptr.ToInt32(pullZoneOriginTypeURL), pz.OriginType); diff != "" {
+							return fmt.Errorf("OriginType differs: %s", diff)
+						}
+
+						return stringsAreEqual("https://bunny.net", pz.OriginURL)
+					}),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_enabled(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(enabled bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	enabled = %t
+}`, pzName, enabled)
+	}
+
+	checkEnabled := func(enabled bool) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "enabled", strconv.FormatBool(enabled)),
+			resource.TestCheckResourceAttr(resourceName, "suspended_reason", ""),
+			checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+				return boolsAreEqual(enabled, pz.Enabled)
+			}),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(true),
+				Check:  checkEnabled(true),
+			},
+			{
+				Config: tf(false),
+				Check:  checkEnabled(false),
+			},
+			{
+				Config: tf(true),
+				Check:  checkEnabled(true),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_createdDisabled(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(enabled bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	enabled = %t
+}`, pzName, enabled)
+	}
+
+	checkEnabled := func(enabled bool) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "enabled", strconv.FormatBool(enabled)),
+			resource.TestCheckResourceAttr(resourceName, "suspended_reason", ""),
+			checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+				return boolsAreEqual(enabled, pz.Enabled)
+			}),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(false),
+				Check:  checkEnabled(false),
+			},
+			{
+				Config: tf(true),
+				Check:  checkEnabled(true),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_updateOnlySendsChangedAttributes(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(followRedirects bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	follow_redirects = %t
+}`, pzName, followRedirects)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enable_geo_zone_sa", "true"),
+					// change a setting that is not part of the
+					// configuration outside of terraform
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						_, err := newAPIClient().PullZone.Update(context.Background(), *pz.ID, &bunny.PullZoneUpdateOptions{
+							EnableGeoZoneSA: ptr.ToBool(false),
+						})
+						return err
+					}),
+				),
+			},
+			{
+				Config: tf(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "follow_redirects", "true"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if err := boolsAreEqual(true, pz.FollowRedirects); err != nil {
+							return fmt.Errorf("FollowRedirects differs: %w", err)
+						}
+
+						return boolsAreEqual(false, pz.EnableGeoZoneSA)
+					}),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_extraSettingsJSON(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+
+	tf := func(useStaleWhileOffline bool) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	extra_settings_json = jsonencode({
+		UseStaleWhileOffline = %t
+	})
+}`, pzName, useStaleWhileOffline)
+	}
+
+	checkUseStaleWhileOffline := func(enabled bool) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(resourceName, "extra_settings_json",
+				fmt.Sprintf(`{"UseStaleWhileOffline":%t}`, enabled),
+			),
+			checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+				return boolsAreEqual(enabled, pz.UseStaleWhileOffline)
+			}),
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(true),
+				Check:  checkUseStaleWhileOffline(true),
+			},
+			{
+				Config: tf(false),
+				Check:  checkUseStaleWhileOffline(false),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccPullZone_extraSettingsJSONConflictsWithModeledAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	extra_settings_json = jsonencode({
+		FollowRedirects = true
+	})
+}`, randResourceName()),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("FollowRedirects are managed by attributes"),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_copySettingsFrom(t *testing.T) {
+	const resourceName = "bunny_pullzone.copy"
+	templateName := randResourceName()
+	copyName := randResourceName()
+
+	tfTemplate := fmt.Sprintf(`
+resource "bunny_pullzone" "template" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+
+	headers {
+		add_host_header = true
+	}
+}
+
+resource "bunny_edgerule" "template" {
+	pull_zone_id = bunny_pullzone.template.id
+	action_type = "block_request"
+	trigger_matching_type = "all"
+	trigger {
+		pattern_matching_type = "any"
+		type = "random_chance"
+		pattern_matches = ["30"]
+	}
+}
+`, templateName)
+
+	tfCopy := tfTemplate + fmt.Sprintf(`
+resource "bunny_pullzone" "copy" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	copy_settings_from = bunny_pullzone.template.id
+	copy_edge_rules = true
+
+	limits {
+		request_limit = 10
+	}
+
+	depends_on = [bunny_edgerule.template]
+}
+`, copyName)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tfTemplate,
+			},
+			{
+				Config: tfCopy,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "copied_settings.#", "4"),
+					resource.TestCheckResourceAttr(resourceName, "copied_settings.0", "headers"),
+					resource.TestCheckResourceAttr(resourceName, "copied_settings.1", "optimizer"),
+					resource.TestCheckResourceAttr(resourceName, "copied_settings.2", "safehop"),
+					resource.TestCheckResourceAttr(resourceName, "copied_settings.3", "edge_rules"),
+					resource.TestCheckResourceAttr(resourceName, "headers.0.add_host_header", "true"),
+					resource.TestCheckResourceAttr(resourceName, "limits.0.request_limit", "10"),
+					checkPullZoneAPI(resourceName, func(pz *bunny.PullZone) error {
+						if err := boolsAreEqual(true, pz.AddHostHeader); err != nil {
+							return fmt.Errorf("AddHostHeader differs: %w", err)
+						}
+
+						if len(pz.EdgeRules) != 1 {
+							return fmt.Errorf("expected pull zone to have 1 edge rule, got: %d", len(pz.EdgeRules))
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				// the template is not evaluated after the pull zone was
+				// created
+				Config:   tfCopy,
+				PlanOnly: true,
+			},
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			checkPullZoneNotExists(templateName),
+			checkPullZoneNotExists(copyName),
+		),
+	})
+}
+
+func TestAccPullZone_crossFieldValidation(t *testing.T) {
+	tf := func(attrs string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+	%s
+}`, randResourceName(), attrs)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	aws_signing_enabled = true
+	aws_signing_key = "key"
+	aws_signing_secret = "secret"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("aws_signing_region_name is required when aws_signing_enabled is true"),
+			},
+			{
+				Config: tf(`
+	log_forwarding_enabled = true
+	log_forwarding_hostname = "logs.example.com"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("log_forwarding_port is required when log_forwarding_enabled is true"),
+			},
+			{
+				Config:      tf(`logging_save_to_storage = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("logging_storage_zone_id is required when logging_save_to_storage is true"),
+			},
+			{
+				Config:      tf(`error_page_enable_custom_code = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("error_page_custom_code is required when error_page_enable_custom_code is true"),
+			},
+			{
+				Config: tf(`
+	type = 1
+	optimizer {
+		enabled = true
+	}
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the optimizer is not supported by Volume Pull Zones"),
+			},
+		},
+	})
+}
+
+func TestAccPullZone_hostnames(t *testing.T) {
+	const resourceName = "bunny_pullzone.testpz"
+	pzName := randResourceName()
+	systemHostname := strings.ToLower(pzName) + ".b-cdn.net"
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "bunny_pullzone" "testpz" {
+	name = "%s"
+	origin_url = "https://bunny.net"
+}`, pzName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "system_hostname", systemHostname),
+					resource.TestCheckResourceAttr(resourceName, "cdn_url", "https://"+systemHostname),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.0.hostname", systemHostname),
+					resource.TestCheckResourceAttr(resourceName, "hostnames.0.is_system_hostname", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "hostnames.0.id"),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
@@ -0,0 +1,486 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	ptr "github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	keyRedirectMapCSVFile           = "csv_file"
+	keyRedirectMapEdgeRuleChecksums = "edge_rule_checksums"
+	keyRedirectMapEdgeRuleGUIDs     = "edge_rule_guids"
+	keyRedirectMapRedirects         = "redirects"
+	keyRedirectMapStatusCode        = "status_code"
+)
+
+// redirectMapDescriptionPrefix is the prefix of the descriptions of the Edge
+// Rules of a redirect map.
+const redirectMapDescriptionPrefix = "redirect map: "
+
+func resourceRedirectMap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRedirectMapCreate,
+		ReadContext:   resourceRedirectMapRead,
+		UpdateContext: resourceRedirectMapUpdate,
+		DeleteContext: resourceRedirectMapDelete,
+
+		CustomizeDiff: redirectMapDiff,
+
+		Schema: map[string]*schema.Schema{
+			keyEdgeRulePullZoneID: {
+				Type:        schema.TypeInt,
+				Description: "The ID of the Pull Zone to that the Edge Rules belong. The resource must not be combined with a bunny_edgerules resource for the same Pull Zone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			keyRedirectMapRedirects: {
+				Type:         schema.TypeMap,
+				Description:  "A map of sources to destinations. Sources and destinations must start with \"/\", \"http://\" or \"https://\", sources must not contain wildcards. Sources starting with \"/\" match exactly the path on every hostname of the Pull Zone, the Edge Rules are updated when hostnames are added or removed. Redirect loops fail planning, also via absolute destinations on a hostname of the Pull Zone.",
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{keyRedirectMapRedirects, keyRedirectMapCSVFile},
+			},
+			keyRedirectMapCSVFile: {
+				Type: schema.TypeString,
+				Description: "Path of a CSV file with the columns source, destination and optionally status_code. " +
+					"Lines starting with # and a header line are ignored. The file is read when planning, changes of its content are applied like changes of redirects.",
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			},
+			keyRedirectMapStatusCode: {
+				Type:        schema.TypeInt,
+				Description: "The HTTP status code of the redirects, for the CSV file it is used for lines without status code.\nValid values: " + intSliceJoin(redirectMapStatusCodes, ", "),
+				Optional:    true,
+				Default:     301,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.IntInSlice(redirectMapStatusCodes),
+				),
+			},
+			keyRedirectMapEdgeRuleGUIDs: {
+				Type:        schema.TypeMap,
+				Description: "The GUIDs of the Edge Rules that the redirects were compiled to, by a key consisting of the status code, destination and a bucket number. Redirects are assigned to buckets by the hash of their source, adding or removing a redirect only changes the Edge Rule of its bucket.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			keyRedirectMapEdgeRuleChecksums: {
+				Type:        schema.TypeMap,
+				Description: "Checksums of the settings of the Edge Rules, by the same keys as edge_rule_guids. Changes of the redirects and of the Edge Rules outside of Terraform are shown as changed checksums, only the Edge Rules with changed checksums are updated.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// redirectMapFromResource returns the configured redirects, either from the
+// redirects attribute or the CSV file. If an error is returned, path is the
+// attribute that caused it.
+func redirectMapFromResource(d resourceDataGetter) (redirects []*redirect, path cty.Path, err error) {
+	statusCode := d.Get(keyRedirectMapStatusCode).(int)
+
+	if csvFile := d.Get(keyRedirectMapCSVFile).(string); csvFile != "" {
+		redirects, err := redirectMapFromCSVFile(csvFile, statusCode)
+		if err != nil {
+			return nil, cty.GetAttrPath(keyRedirectMapCSVFile), fmt.Errorf("reading %s failed: %w", csvFile, err)
+		}
+
+		return redirects, cty.GetAttrPath(keyRedirectMapCSVFile), nil
+	}
+
+	return redirectMapFromMap(d.Get(keyRedirectMapRedirects).(map[string]interface{}), statusCode),
+		cty.GetAttrPath(keyRedirectMapRedirects), nil
+}
+
+// redirectMapValidRedirects returns the configured redirects, if they are
+// valid, see redirectMapValidate.
+func redirectMapValidRedirects(d resourceDataGetter, hostnames []string) ([]*redirect, error) {
+	redirects, path, err := redirectMapFromResource(d)
+	if err != nil {
+		return nil, path.NewError(err)
+	}
+
+	var errs attrErrors
+	for _, err := range redirectMapValidate(redirects, hostnames) {
+		errs.add(path, "%s", err)
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+// redirectMapRules returns the compiled and validated Edge Rules of the
+// redirect map by their key. hostnames are the hostnames of the Pull Zone.
+func redirectMapRules(d resourceDataGetter, hostnames []string) (map[string]*addOrUpdateEdgeRuleOptions, error) {
+	redirects, err := redirectMapValidRedirects(d, hostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	return redirectMapCompile(redirects, hostnames, redirectMapDescriptionPrefix), nil
+}
+
+// redirectMapHostnames retrieves the hostnames of the Pull Zone in
+// alphabetical order.
+func redirectMapHostnames(ctx context.Context, clt *apiClient, pullZoneID int64) ([]string, error) {
+	pz, err := clt.pullZoneGet(ctx, pullZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving pull zone failed: %w", err)
+	}
+
+	res := make([]string, 0, len(pz.Hostnames))
+	for _, h := range pz.Hostnames {
+		if hostname := ptr.GetString(h.Value); hostname != "" {
+			res = append(res, strings.ToLower(hostname))
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("pull zone %d has no hostnames", pullZoneID)
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// redirectMapChecksums returns the checksums of the Edge Rules by their key.
+func redirectMapChecksums(rules map[string]*addOrUpdateEdgeRuleOptions) map[string]interface{} {
+	res := make(map[string]interface{}, len(rules))
+
+	for k, opts := range rules {
+		res[k] = edgeRuleChecksum(edgeRuleFromOptions(opts))
+	}
+
+	return res
+}
+
+// redirectMapDiff validates the redirects and plans the changes of the
+// Edge Rules. The redirects are compiled into Edge Rules, Edge Rules whose
+// checksum differs from the one in the state are changed. When Edge Rules
+// are added or removed, their GUIDs become unknown.
+// The patterns of the Edge Rules depend on the hostnames of the Pull Zone,
+// changed hostnames are therefore planned as changed checksums too.
+func redirectMapDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{keyRedirectMapRedirects, keyRedirectMapCSVFile, keyRedirectMapStatusCode} {
+		if !d.NewValueKnown(key) {
+			if err := d.SetNewComputed(keyRedirectMapEdgeRuleChecksums); err != nil {
+				return err
+			}
+
+			return d.SetNewComputed(keyRedirectMapEdgeRuleGUIDs)
+		}
+	}
+
+	// The redirects are validated before the hostnames are retrieved, to
+	// report invalid redirects also for Pull Zones that do not exist yet.
+	if _, err := redirectMapValidRedirects(d, nil); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown(keyEdgeRulePullZoneID) {
+		if err := d.SetNewComputed(keyRedirectMapEdgeRuleChecksums); err != nil {
+			return err
+		}
+
+		return d.SetNewComputed(keyRedirectMapEdgeRuleGUIDs)
+	}
+
+	hostnames, err := redirectMapHostnames(ctx, meta.(*providerMeta).api, int64(d.Get(keyEdgeRulePullZoneID).(int)))
+	if err != nil {
+		return err
+	}
+
+	rules, err := redirectMapRules(d, hostnames)
+	if err != nil {
+		return err
+	}
+
+	oldChecksums, _ := d.GetChange(keyRedirectMapEdgeRuleChecksums)
+	newChecksums := redirectMapChecksums(rules)
+
+	if reflect.DeepEqual(oldChecksums.(map[string]interface{}), newChecksums) {
+		return nil
+	}
+
+	if err := d.SetNew(keyRedirectMapEdgeRuleChecksums, newChecksums); err != nil {
+		return err
+	}
+
+	if !strMapKeysEqual(oldChecksums.(map[string]interface{}), newChecksums) {
+		return d.SetNewComputed(keyRedirectMapEdgeRuleGUIDs)
+	}
+
+	return nil
+}
+
+func resourceRedirectMapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	d.SetId(uuid.New().String())
+
+	diags := redirectMapApply(ctx, d, pm, map[string]interface{}{}, map[string]interface{}{})
+	if diags.HasError() {
+		guids := d.Get(keyRedirectMapEdgeRuleGUIDs).(map[string]interface{})
+		if len(guids) == 0 {
+			d.SetId("")
+			return diags
+		}
+
+		return createFailed(ctx, d, meta, "redirect map", diags,
+			func(ctx context.Context) error {
+				defer edgeRuleLocks.lock(pullZoneID)()
+
+				return errFromDiags(edgeRulesDelete(ctx, pm, pullZoneID, strMapValues(guids)))
+			},
+		)
+	}
+
+	return append(diags, resourceRedirectMapRead(ctx, d, meta)...)
+}
+
+func resourceRedirectMapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oldGUIDs, _ := d.GetChange(keyRedirectMapEdgeRuleGUIDs)
+	oldChecksums, _ := d.GetChange(keyRedirectMapEdgeRuleChecksums)
+
+	diags := redirectMapApply(ctx, d, meta.(*providerMeta),
+		oldGUIDs.(map[string]interface{}), oldChecksums.(map[string]interface{}),
+	)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceRedirectMapRead(ctx, d, meta)...)
+}
+
+// redirectMapApply changes the Edge Rules of the redirect map to the compiled
+// ones. guids and checksums are the GUIDs and checksums of the existing Edge
+// Rules. Only Edge Rules with a different checksum are updated, missing ones
+// are created and Edge Rules that are not needed anymore are deleted.
+// The GUIDs and checksums of the Edge Rules are stored in d, also if an
+// error happens.
+func redirectMapApply(
+	ctx context.Context,
+	d *schema.ResourceData,
+	pm *providerMeta,
+	guids, checksums map[string]interface{},
+) diag.Diagnostics {
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	hostnames, err := redirectMapHostnames(ctx, pm.api, pullZoneID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rules, err := redirectMapRules(d, hostnames)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	guids = copyStrMap(guids)
+	checksums = copyStrMap(checksums)
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	diags := redirectMapApplyRules(ctx, pm, pullZoneID, rules, guids, checksums)
+
+	if err := d.Set(keyRedirectMapEdgeRuleGUIDs, guids); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := d.Set(keyRedirectMapEdgeRuleChecksums, checksums); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// redirectMapApplyRules applies the rules and updates guids and checksums
+// after each successful change. The Pull Zone must be locked via
+// edgeRuleLocks.
+func redirectMapApplyRules(
+	ctx context.Context,
+	pm *providerMeta,
+	pullZoneID int64,
+	rules map[string]*addOrUpdateEdgeRuleOptions,
+	guids, checksums map[string]interface{},
+) diag.Diagnostics {
+	edgeRules, err := pm.api.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving pull zone failed", err)
+	}
+
+	var diags diag.Diagnostics
+
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		opts := rules[k]
+		checksum := edgeRuleChecksum(edgeRuleFromOptions(opts))
+
+		guid, _ := guids[k].(string)
+		if guid != "" && checksums[k] == checksum {
+			continue
+		}
+
+		if guid != "" && edgeRuleByGUID(edgeRules, guid) != nil {
+			logger.Infof("pull zone %d: updating redirect edge rule %q (%s)", pullZoneID, guid, k)
+
+			withGUID := *opts
+			withGUID.GUID = &guid
+
+			var updateDiags diag.Diagnostics
+			edgeRules, updateDiags = edgeRuleUpdateVerified(ctx, pm, pullZoneID, edgeRules, &withGUID)
+			diags = append(diags, updateDiags...)
+			if diags.HasError() {
+				return diags
+			}
+
+			checksums[k] = checksum
+			continue
+		}
+
+		logger.Infof("pull zone %d: creating redirect edge rule %s", pullZoneID, k)
+
+		var addDiags diag.Diagnostics
+		guid, edgeRules, addDiags = edgeRuleAddVerified(ctx, pm, pullZoneID, edgeRules, opts)
+		diags = append(diags, addDiags...)
+		if diags.HasError() {
+			if guid != "" {
+				// the checksum is not stored, the Edge Rule is updated
+				// by the next apply
+				guids[k] = guid
+			}
+
+			return diags
+		}
+
+		guids[k] = guid
+		checksums[k] = checksum
+	}
+
+	for k, guid := range guids {
+		if _, exists := rules[k]; exists {
+			continue
+		}
+
+		logger.Infof("pull zone %d: deleting redirect edge rule %q (%s)", pullZoneID, guid, k)
+
+		diags = append(diags, edgeRulesDelete(ctx, pm, pullZoneID, []string{guid.(string)})...)
+		if diags.HasError() {
+			return diags
+		}
+
+		delete(guids, k)
+		delete(checksums, k)
+	}
+
+	return diags
+}
+
+// resourceRedirectMapRead retrieves the Edge Rules of the redirect map and
+// stores their checksums. Edge Rules that do not exist anymore are removed
+// from the state.
+func resourceRedirectMapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clt := meta.(*providerMeta).api
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+
+	edgeRules, err := clt.pullZoneEdgeRules(ctx, pullZoneID)
+	if err != nil {
+		return diagsErrFromErr("retrieving pull zone failed", err)
+	}
+
+	guids := map[string]interface{}{}
+	checksums := map[string]interface{}{}
+
+	for k, guid := range d.Get(keyRedirectMapEdgeRuleGUIDs).(map[string]interface{}) {
+		er := edgeRuleByGUID(edgeRules, guid.(string))
+		if er == nil {
+			logger.Infof("pull zone %d: redirect edge rule %q (%s) does not exist anymore", pullZoneID, guid, k)
+			continue
+		}
+
+		guids[k] = guid
+		checksums[k] = edgeRuleChecksum(er)
+	}
+
+	if err := d.Set(keyRedirectMapEdgeRuleGUIDs, guids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(keyRedirectMapEdgeRuleChecksums, checksums); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceRedirectMapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	pm := meta.(*providerMeta)
+	pullZoneID := int64(d.Get(keyEdgeRulePullZoneID).(int))
+	guids := strMapValues(d.Get(keyRedirectMapEdgeRuleGUIDs).(map[string]interface{}))
+
+	defer edgeRuleLocks.lock(pullZoneID)()
+
+	diags := edgeRulesDelete(ctx, pm, pullZoneID, guids)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// strMapKeysEqual returns true if a and b have the same keys.
+func strMapKeysEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k := range a {
+		if _, exists := b[k]; !exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// strMapValues returns the values of m, sorted.
+func strMapValues(m map[string]interface{}) []string {
+	res := make([]string, 0, len(m))
+	for _, v := range m {
+		res = append(res, v.(string))
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func copyStrMap(m map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+
+	return res
+}
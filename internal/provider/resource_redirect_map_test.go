@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRedirectMap_basic(t *testing.T) {
+	const resourceName = "bunny_redirect_map.migration"
+	var blogGUID string
+	pzName := randResourceName()
+
+	csvFile := filepath.Join(t.TempDir(), "redirects.csv")
+	err := os.WriteFile(csvFile, []byte("source,destination,status_code\n/old-blog,/blog\n/tmp,/new,302\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf := func(redirects string) string {
+		return fmt.Sprintf(`
+resource "bunny_pullzone" "mypz" {
+	name = "%s"
+	origin_url ="https://bunny.net"
+}
+
+resource "bunny_redirect_map" "migration" {
+	pull_zone_id = bunny_pullzone.mypz.id
+%s
+}`, pzName, redirects)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	redirects = {
+		"/old-blog"  = "/blog"
+		"/news"      = "/blog"
+		"/old-about" = "/about"
+	}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"redirect map: 301 /about #1",
+						"redirect map: 301 /blog #1",
+					),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_guids.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_checksums.%", "2"),
+					func(s *terraform.State) error {
+						blogGUID = s.RootModule().Resources[resourceName].Primary.Attributes["edge_rule_guids.301 /blog #1"]
+						return nil
+					},
+				),
+			},
+			{
+				// only the edge rule of the changed destination is updated,
+				// the edge rule of /blog keeps its GUID
+				Config: tf(`
+	redirects = {
+		"/old-blog"  = "/blog"
+		"/news"      = "/blog"
+		"/old-about" = "/about"
+		"/team"      = "/about"
+	}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"redirect map: 301 /about #1",
+						"redirect map: 301 /blog #1",
+					),
+					func(s *terraform.State) error {
+						guid := s.RootModule().Resources[resourceName].Primary.Attributes["edge_rule_guids.301 /blog #1"]
+						if guid != blogGUID {
+							return fmt.Errorf("guid of the unchanged edge rule changed from %q to %q", blogGUID, guid)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				Config: tf(fmt.Sprintf(`
+	csv_file = %q
+`, csvFile)),
+				Check: resource.ComposeTestCheckFunc(
+					checkEdgeRulesOrder("bunny_pullzone.mypz",
+						"redirect map: 301 /blog #1",
+						"redirect map: 302 /new #1",
+					),
+					resource.TestCheckResourceAttr(resourceName, "edge_rule_guids.%", "2"),
+				),
+			},
+		},
+		CheckDestroy: checkPullZoneNotExists(pzName),
+	})
+}
+
+func TestAccRedirectMap_validation(t *testing.T) {
+	tf := func(redirects string) string {
+		return fmt.Sprintf(`
+resource "bunny_redirect_map" "m" {
+	pull_zone_id = 1
+%s
+}`, redirects)
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tf(`
+	redirects = {
+		"/a" = "/b"
+		"/b" = "/a"
+	}
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`redirect loop: /a -> /b -> /a`),
+			},
+			{
+				Config: tf(`
+	redirects = {
+		"/a/*" = "/b"
+	}
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must not contain wildcards`),
+			},
+			{
+				Config: tf(`
+	csv_file = "does-not-exist.csv"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`reading does-not-exist.csv failed`),
+			},
+		},
+	})
+}
+
+func TestRedirectMapDiffAttributePath(t *testing.T) {
+	r := resourceRedirectMap()
+	cfg := testObjectVal(r, r.CoreConfigSchema().ImpliedType(), map[string]cty.Value{
+		keyEdgeRulePullZoneID: cty.NumberIntVal(1),
+		keyRedirectMapRedirects: cty.MapVal(map[string]cty.Value{
+			"/a": cty.StringVal("/b"),
+			"/b": cty.StringVal("/a"),
+			"/c": cty.StringVal("c"),
+		}),
+	})
+
+	path := testDiagAttributePath(t, testPlanCreate(t, "bunny_redirect_map", cfg))
+
+	want := tftypes.NewAttributePath().WithAttributeName(keyRedirectMapRedirects)
+	if !path.Equal(want) {
+		t.Errorf("expected the diagnostic for attribute %s, got: %s", want, path)
+	}
+}
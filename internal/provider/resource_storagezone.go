@@ -276,7 +276,7 @@ func creatingRegionWithReplicationInSameRegionError(region string) error {
 }
 
 func resourceStorageZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	originURL := getStrPtr(d, keyOriginURL)
 	if !d.HasChange(keyOriginURL) {
@@ -313,14 +313,16 @@ func resourceStorageZoneCreate(ctx context.Context, d *schema.ResourceData, meta
 			})
 		}
 
-		return diags
+		return createFailed(ctx, d, meta, "storage zone", diags, func(ctx context.Context) error {
+			return clt.StorageZone.Delete(ctx, *sz.ID)
+		})
 	}
 
 	return nil
 }
 
 func resourceStorageZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	storageZone := storageZoneFromResource(d)
 
@@ -343,7 +345,7 @@ func resourceStorageZoneUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
@@ -363,7 +365,7 @@ func resourceStorageZoneRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceStorageZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clt := meta.(*bunny.Client)
+	clt := meta.(*providerMeta).client
 
 	id, err := getIDAsInt64(d)
 	if err != nil {
@@ -60,6 +60,11 @@ func (m structure) getStrPtr(key string) *string {
 	return ptr.ToString(m[key].(string))
 }
 
+// getInt returns the value of the passed key as int.
+func (m structure) getInt(key string) int {
+	return m[key].(int)
+}
+
 func (m structure) getIntPtr(key string) *int {
 	return ptr.ToInt(m[key].(int))
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 )
 
 // strIntMapKeysSorted returns a sorted []string containing the keys of m.
@@ -46,5 +47,35 @@ func intStrMapGet(m map[int]string, key *int) (string, error) {
 		return v, nil
 	}
 
-	return "", fmt.Errorf("key '%d' not found", key)
+	return "", fmt.Errorf("key '%d' not found", *key)
+}
+
+// intStrMapGetOrRaw is like intStrMapGet, but returns the key formatted as
+// number if it does not exist in m. It is used for types that are returned
+// by the API but are not supported by the provider, to report them instead
+// of failing.
+func intStrMapGetOrRaw(m map[int]string, key *int) (string, error) {
+	if key == nil {
+		return "", errors.New("key is nil")
+	}
+
+	if v, exists := m[*key]; exists {
+		return v, nil
+	}
+
+	return strconv.Itoa(*key), nil
+}
+
+// strIntMapGetOrRaw is like strIntMapGet, but also accepts the numeric
+// values returned by intStrMapGetOrRaw.
+func strIntMapGetOrRaw(m map[string]int, key string) (int, error) {
+	if v, exists := m[key]; exists {
+		return v, nil
+	}
+
+	if v, err := strconv.Atoi(key); err == nil {
+		return v, nil
+	}
+
+	return -1, fmt.Errorf("key %q not found", key)
 }
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"testing"
+
+	ptr "github.com/AlekSi/pointer"
+)
+
+func TestStrIntMapRoundTrip(t *testing.T) {
+	testcases := []struct {
+		name   string
+		strInt map[string]int
+		intStr map[int]string
+	}{
+		{name: "action types", strInt: edgeRuleActionTypesStr, intStr: edgeRuleActionTypesInt},
+		{name: "trigger types", strInt: edgeRuleTriggerTypesStr, intStr: edgeRuleTriggerTypesInt},
+		{name: "matching types", strInt: edgeRuleMatchingTypesStr, intStr: edgeRuleMatchingTypesInt},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if len(tc.strInt) != len(tc.intStr) {
+				t.Fatalf("maps have different lengths, %d string keys, %d int keys, values are not unique",
+					len(tc.strInt), len(tc.intStr))
+			}
+
+			for str, i := range tc.strInt {
+				got, err := intStrMapGet(tc.intStr, ptr.ToInt(i))
+				if err != nil {
+					t.Errorf("intStrMapGet(%d) returned error: %s", i, err)
+					continue
+				}
+
+				if got != str {
+					t.Errorf("intStrMapGet(%d) returned %q, expected %q", i, got, str)
+				}
+
+				gotInt, err := strIntMapGet(tc.strInt, got)
+				if err != nil {
+					t.Errorf("strIntMapGet(%q) returned error: %s", got, err)
+					continue
+				}
+
+				if gotInt != i {
+					t.Errorf("strIntMapGet(%q) returned %d, expected %d", got, gotInt, i)
+				}
+			}
+		})
+	}
+}
+
+func TestIntStrMapGetErrors(t *testing.T) {
+	m := map[int]string{1: "one"}
+
+	if _, err := intStrMapGet(m, nil); err == nil {
+		t.Error("expected an error for a nil key")
+	}
+
+	_, err := intStrMapGet(m, ptr.ToInt(2))
+	if err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+
+	if want := "key '2' not found"; err.Error() != want {
+		t.Errorf("unexpected error message %q, expected %q", err, want)
+	}
+}
+
+func TestStrIntMapGetError(t *testing.T) {
+	v, err := strIntMapGet(map[string]int{"one": 1}, "two")
+	if err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+
+	if v != -1 {
+		t.Errorf("expected -1 for an unknown key, got %d", v)
+	}
+}
+
+func TestIntStrMapGetOrRaw(t *testing.T) {
+	name, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, ptr.ToInt(edgeRuleActionTypeRunEdgeScript))
+	if err != nil || name != "run_edge_script" {
+		t.Errorf("expected run_edge_script, got %q, error: %v", name, err)
+	}
+
+	raw, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, ptr.ToInt(99))
+	if err != nil || raw != "99" {
+		t.Errorf("expected the raw value 99 for an unknown action type, got %q, error: %v", raw, err)
+	}
+
+	if _, err := intStrMapGetOrRaw(edgeRuleActionTypesInt, nil); err == nil {
+		t.Error("expected an error for a nil key")
+	}
+
+	for _, tc := range []struct {
+		key  string
+		want int
+	}{
+		{key: "run_edge_script", want: edgeRuleActionTypeRunEdgeScript},
+		{key: raw, want: 99},
+	} {
+		got, err := strIntMapGetOrRaw(edgeRuleActionTypesStr, tc.key)
+		if err != nil || got != tc.want {
+			t.Errorf("strIntMapGetOrRaw(%q) returned %d, error: %v, expected %d", tc.key, got, err, tc.want)
+		}
+	}
+
+	if _, err := strIntMapGetOrRaw(edgeRuleActionTypesStr, "unknown"); err == nil {
+		t.Error("expected an error for an unknown name")
+	}
+}
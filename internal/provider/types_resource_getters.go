@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -88,3 +90,147 @@ func getIDAsInt64(d *schema.ResourceData) (int64, error) {
 func getStrSetAsSlice(d *schema.ResourceData, key string) []string {
 	return strSetAsSlice(d.Get(key))
 }
+
+// isBlockConfigured returns true if the configuration contains the block with
+// the passed key.
+// In contrast to d.GetOk(), it reports false for blocks that are only
+// present in the state.
+func isBlockConfigured(d *schema.ResourceData, key string) bool {
+	cfg := d.GetRawConfig()
+	if cfg.IsNull() || !cfg.IsKnown() {
+		return false
+	}
+
+	block := cfg.GetAttr(key)
+	if block.IsNull() || !block.IsKnown() {
+		return false
+	}
+
+	return block.LengthInt() > 0
+}
+
+// isConfiguredInDiff returns true if the configuration contains a value for
+// the top-level attribute with the passed key.
+func isConfiguredInDiff(d *schema.ResourceDiff, key string) bool {
+	cfg := d.GetRawConfig()
+	if cfg.IsNull() || !cfg.IsKnown() {
+		return false
+	}
+
+	v := cfg.GetAttr(key)
+	if v.IsNull() {
+		return false
+	}
+
+	if v.IsKnown() && (v.Type().IsListType() || v.Type().IsTupleType()) {
+		return v.LengthInt() > 0
+	}
+
+	return true
+}
+
+// isConfigured returns true if the configuration contains a value for the
+// attribute with the passed path. The elements of the path are separated by
+// dots, list elements are referenced by their index (e.g. "origin.0.url").
+// In contrast to d.GetOk(), it reports true for zero values and false for
+// values that are only present in the state.
+func isConfigured(d *schema.ResourceData, path string) bool {
+	v := d.GetRawConfig()
+
+	for _, elem := range strings.Split(path, ".") {
+		if v.IsNull() || !v.IsKnown() {
+			return false
+		}
+
+		if !v.Type().IsListType() && !v.Type().IsTupleType() {
+			v = v.GetAttr(elem)
+			continue
+		}
+
+		idx, err := strconv.Atoi(elem)
+		if err != nil || idx < 0 || idx >= v.LengthInt() {
+			return false
+		}
+
+		v = v.Index(cty.NumberIntVal(int64(idx)))
+	}
+
+	return !v.IsNull()
+}
+
+// changedAttrGetter returns the values of the attributes that must be sent to
+// the API when a resource is created or updated, for all other attributes
+// nil is returned.
+// When the resource is created, these are the attributes that are set in the
+// configuration or have a default value. When the resource is updated, these
+// are the attributes that changed.
+type changedAttrGetter struct {
+	d      *schema.ResourceData
+	schema map[string]*schema.Schema
+}
+
+func newChangedAttrGetter(d *schema.ResourceData, s map[string]*schema.Schema) *changedAttrGetter {
+	return &changedAttrGetter{d: d, schema: s}
+}
+
+// changed returns true if the value of the attribute or block with the
+// passed key must be sent to the API.
+func (c *changedAttrGetter) changed(key string) bool {
+	if !c.d.IsNewResource() {
+		return c.d.HasChange(key)
+	}
+
+	if sch, exists := c.schema[key]; exists && sch.Default != nil {
+		return true
+	}
+
+	return isConfigured(c.d, key)
+}
+
+func (c *changedAttrGetter) boolPtr(key string) *bool {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getBoolPtr(c.d, key)
+}
+
+func (c *changedAttrGetter) strPtr(key string) *string {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getStrPtr(c.d, key)
+}
+
+func (c *changedAttrGetter) intPtr(key string) *int {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getIntPtr(c.d, key)
+}
+
+func (c *changedAttrGetter) int32Ptr(key string) *int32 {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getInt32Ptr(c.d, key)
+}
+
+func (c *changedAttrGetter) int64Ptr(key string) *int64 {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getInt64Ptr(c.d, key)
+}
+
+func (c *changedAttrGetter) strSetAsSlice(key string) []string {
+	if !c.changed(key) {
+		return nil
+	}
+
+	return getStrSetAsSlice(c.d, key)
+}
@@ -1,9 +1,139 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"math"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var validateIsInt32 = validation.ToDiagFunc(validation.IntBetween(math.MinInt32, math.MaxInt32))
+
+// attrErrors collects errors that refer to attributes, the elements are
+// cty.PathError values.
+type attrErrors []error
+
+func (errs *attrErrors) add(path cty.Path, format string, a ...interface{}) {
+	*errs = append(*errs, path.NewErrorf(format, a...))
+}
+
+// err returns nil if no errors were added. A single error is returned as
+// cty.PathError, the SDK turns it into a diagnostic for the attribute if the
+// error is returned unwrapped by the CustomizeDiff function, see
+// customizeDiffAll. Errors of the same attribute are combined into one
+// cty.PathError.
+// A CustomizeDiff function can only return a single diagnostic, errors of
+// different attributes are combined into one error without path, the message
+// of each error is prefixed with its attribute path.
+func (errs attrErrors) err() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+
+	if path, ok := errs.commonPath(); ok {
+		msgs := make([]string, 0, len(errs))
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+
+		return path.NewError(errors.New(strings.Join(msgs, "\n")))
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var pathErr cty.PathError
+		if errors.As(err, &pathErr) && len(pathErr.Path) > 0 {
+			msgs = append(msgs, ctyPathString(pathErr.Path)+": "+err.Error())
+			continue
+		}
+
+		msgs = append(msgs, err.Error())
+	}
+
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// commonPath returns the path of the errors if all errors refer to the same
+// attribute.
+func (errs attrErrors) commonPath() (cty.Path, bool) {
+	var res cty.Path
+
+	for i, err := range errs {
+		var pathErr cty.PathError
+		if !errors.As(err, &pathErr) || len(pathErr.Path) == 0 {
+			return nil, false
+		}
+
+		if i == 0 {
+			res = pathErr.Path
+			continue
+		}
+
+		if !res.Equals(pathErr.Path) {
+			return nil, false
+		}
+	}
+
+	return res, len(res) > 0
+}
+
+// customizeDiffAll returns a CustomizeDiffFunc that runs all funcs, like
+// customdiff.All. Unlike customdiff.All, a single error is returned
+// unwrapped, a cty.PathError is then reported for its attribute instead of
+// for the resource. Multiple errors are combined into one error.
+func customizeDiffAll(funcs ...schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		var errs []error
+
+		for _, f := range funcs {
+			if err := f(ctx, d, meta); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		switch len(errs) {
+		case 0:
+			return nil
+		case 1:
+			return errs[0]
+		}
+
+		msgs := make([]string, 0, len(errs))
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+}
+
+// ctyPathString returns the path in the dot-separated notation of
+// ResourceData keys, e.g. "origin.0.url".
+func ctyPathString(path cty.Path) string {
+	elems := make([]string, 0, len(path))
+
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			elems = append(elems, s.Name)
+		case cty.IndexStep:
+			switch s.Key.Type() {
+			case cty.Number:
+				i, _ := s.Key.AsBigFloat().Int64()
+				elems = append(elems, strconv.FormatInt(i, 10))
+			case cty.String:
+				elems = append(elems, s.Key.AsString())
+			}
+		}
+	}
+
+	return strings.Join(elems, ".")
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testObjectVal returns an object of type ty with the values of vals.
+// Attributes that are not in vals are null, blocks are empty.
+func testObjectVal(r *schema.Resource, ty cty.Type, vals map[string]cty.Value) cty.Value {
+	res := make(map[string]cty.Value, len(ty.AttributeTypes()))
+
+	for name, attrTy := range ty.AttributeTypes() {
+		if v, exists := vals[name]; exists {
+			res[name] = v
+			continue
+		}
+
+		var isBlock bool
+		if s, exists := r.Schema[name]; exists {
+			_, isBlock = s.Elem.(*schema.Resource)
+		}
+
+		if !isBlock {
+			res[name] = cty.NullVal(attrTy)
+			continue
+		}
+
+		switch {
+		case attrTy.IsListType():
+			res[name] = cty.ListValEmpty(attrTy.ElementType())
+		case attrTy.IsSetType():
+			res[name] = cty.SetValEmpty(attrTy.ElementType())
+		default:
+			res[name] = cty.NullVal(attrTy)
+		}
+	}
+
+	return cty.ObjectVal(res)
+}
+
+// testPlanCreate plans the creation of a resource of the type with the
+// configuration cfg via the gRPC provider server and returns the
+// diagnostics. Unlike resource.Test with ExpectError, the attribute paths of
+// the diagnostics can be checked.
+func testPlanCreate(t *testing.T, resourceType string, cfg cty.Value) []*tfprotov5.Diagnostic {
+	t.Helper()
+
+	p := New()
+	ty := p.ResourcesMap[resourceType].CoreConfigSchema().ImpliedType()
+
+	dynamicValue := func(v cty.Value) *tfprotov5.DynamicValue {
+		buf, err := msgpack.Marshal(v, ty)
+		if err != nil {
+			t.Fatalf("encoding value failed: %s", err)
+		}
+
+		return &tfprotov5.DynamicValue{MsgPack: buf}
+	}
+
+	resp, err := schema.NewGRPCProviderServer(p).PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       dynamicValue(cty.NullVal(ty)),
+		ProposedNewState: dynamicValue(cfg),
+		Config:           dynamicValue(cfg),
+	})
+	if err != nil {
+		t.Fatalf("planning failed: %s", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// testDiagAttributePath returns the attribute path of the only error
+// diagnostic.
+func testDiagAttributePath(t *testing.T, diags []*tfprotov5.Diagnostic) *tftypes.AttributePath {
+	t.Helper()
+
+	var res []*tfprotov5.Diagnostic
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			res = append(res, d)
+		}
+	}
+
+	if len(res) != 1 {
+		for _, d := range res {
+			t.Logf("error: %s", d.Summary)
+		}
+		t.Fatalf("expected 1 error diagnostic, got %d", len(res))
+	}
+
+	if res[0].Attribute == nil {
+		t.Fatalf("diagnostic %q has no attribute path", res[0].Summary)
+	}
+
+	return res[0].Attribute
+}
+
+func TestCtyPathString(t *testing.T) {
+	path := cty.GetAttrPath("trigger").Index(cty.NumberIntVal(2)).GetAttr("pattern_matches").Index(cty.StringVal("x"))
+
+	if s := ctyPathString(path); s != "trigger.2.pattern_matches.x" {
+		t.Errorf("unexpected path string: %q", s)
+	}
+}
+
+func TestAttrErrors(t *testing.T) {
+	var errs attrErrors
+	if errs.err() != nil {
+		t.Fatal("expected no error for empty attrErrors")
+	}
+
+	errs.add(cty.GetAttrPath("a"), "first")
+	errs.add(cty.GetAttrPath("a"), "second")
+
+	var pathErr cty.PathError
+	if err := errs.err(); !errors.As(err, &pathErr) || !pathErr.Path.Equals(cty.GetAttrPath("a")) {
+		t.Errorf("expected errors of the same attribute to be combined into an error for the attribute, got: %#v", err)
+	}
+
+	errs.add(cty.GetAttrPath("b").IndexInt(1), "third")
+
+	err := errs.err()
+	if errors.As(err, &pathErr) {
+		t.Errorf("expected errors of different attributes to be combined into an error without path, got: %#v", err)
+	}
+
+	if want := "a: first\na: second\nb.1: third"; err.Error() != want {
+		t.Errorf("expected error message %q, got: %q", want, err.Error())
+	}
+}